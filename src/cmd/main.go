@@ -81,6 +81,12 @@ func Run(content embed.FS) {
 				Usage: "Specify the path to a different hotkey file",
 				Value: "", // Default to the blank string indicating non-usage of flag
 			},
+			&cli.StringFlag{
+				Name:    "session",
+				Aliases: []string{"s"},
+				Usage:   "Restore and save the named session, even if session_restore is disabled",
+				Value:   "", // Default to the blank string indicating non-usage of flag
+			},
 		},
 		Action: func(c *cli.Context) error {
 			// If no args are called along with "spf" use current dir
@@ -134,6 +140,7 @@ func InitConfigFile() {
 		variable.SuperFileDataDir,
 		variable.SuperFileStateDir,
 		variable.ThemeFolder,
+		variable.SessionDir,
 	); err != nil {
 		utils.PrintlnAndExit("Error creating directories:", err)
 	}
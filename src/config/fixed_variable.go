@@ -21,6 +21,8 @@ const (
 	EmbedHotkeysFile         = EmbedConfigDir + "/hotkeys.toml"
 	EmbedThemeDir            = EmbedConfigDir + "/theme"
 	EmbedThemeCatppuccinFile = EmbedThemeDir + "/catppuccin.toml"
+
+	DefaultSessionName = "default"
 )
 
 var (
@@ -34,12 +36,17 @@ var (
 	ThemeFolder = filepath.Join(SuperFileMainDir, "theme")
 
 	// DataDir files
-	LastCheckVersion = filepath.Join(SuperFileDataDir, "lastCheckVersion")
-	ThemeFileVersion = filepath.Join(SuperFileDataDir, "themeFileVersion")
-	FirstUseCheck    = filepath.Join(SuperFileDataDir, "firstUseCheck")
-	PinnedFile       = filepath.Join(SuperFileDataDir, "pinned.json")
-	ToggleDotFile    = filepath.Join(SuperFileDataDir, "toggleDotFile")
-	ToggleFooter     = filepath.Join(SuperFileDataDir, "toggleFooter")
+	LastCheckVersion  = filepath.Join(SuperFileDataDir, "lastCheckVersion")
+	ThemeFileVersion  = filepath.Join(SuperFileDataDir, "themeFileVersion")
+	FirstUseCheck     = filepath.Join(SuperFileDataDir, "firstUseCheck")
+	PinnedFile        = filepath.Join(SuperFileDataDir, "pinned.json")
+	ToggleDotFile     = filepath.Join(SuperFileDataDir, "toggleDotFile")
+	ToggleFooter      = filepath.Join(SuperFileDataDir, "toggleFooter")
+	SessionDir        = filepath.Join(SuperFileDataDir, "sessions")
+	FrecencyFile      = filepath.Join(SuperFileDataDir, "frecency.json")
+	MarksFile         = filepath.Join(SuperFileDataDir, "marks.json")
+	DirectorySortFile = filepath.Join(SuperFileDataDir, "directory_sort.json")
+	TagsFile          = filepath.Join(SuperFileDataDir, "tags.json")
 
 	// StateDir files
 	LogFile     = filepath.Join(SuperFileStateDir, "superfile.log")
@@ -56,12 +63,15 @@ var (
 var (
 	ConfigFile  = filepath.Join(SuperFileMainDir, "config.toml")
 	HotkeysFile = filepath.Join(SuperFileMainDir, "hotkeys.toml")
+	SessionFile = filepath.Join(SessionDir, DefaultSessionName+".json")
 
 	// Other state variables
 	FixHotkeys    = false
 	FixConfigFile = false
 	LastDir       = ""
 	PrintLastDir  = false
+	// Whether a session was requested via the --session flag
+	SessionFromArg = false
 )
 
 // Still we are preventing other packages to directly modify them via reassign linter
@@ -91,6 +101,16 @@ func UpdateVarFromCliArgs(c *cli.Context) {
 		HotkeysFile = hotkeyFileArg
 	}
 
+	sessionArg := c.String("session")
+
+	if sessionArg != "" {
+		if sessionArg != filepath.Base(sessionArg) || sessionArg == "." || sessionArg == ".." {
+			utils.PrintfAndExit("Error: Invalid session name '%s', it must not contain path separators", sessionArg)
+		}
+		SessionFile = filepath.Join(SessionDir, sessionArg+".json")
+		SessionFromArg = true
+	}
+
 	FixHotkeys = c.Bool("fix-hotkeys")
 	FixConfigFile = c.Bool("fix-config-file")
 	PrintLastDir = c.Bool("print-last-dir")
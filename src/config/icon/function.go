@@ -25,6 +25,8 @@ func InitIcon(nerdfont bool, directoryIconColor string) {
 		Cursor = ">"
 		Browser = ""
 		Select = ""
+		Tree = ""
+		Flatten = ""
 		Error = ""
 		Warn = ""
 		Done = ""
@@ -33,6 +35,11 @@ func InitIcon(nerdfont bool, directoryIconColor string) {
 		Search = ""
 		SortAsc = ""
 		SortDesc = ""
+		History = ""
+		Bookmark = ""
+		GitBranch = ""
+		Tag = ""
+		HideIgnored = ""
 	}
 
 	if directoryIconColor == "" {
@@ -31,6 +31,8 @@ var (
 	Cursor      = "\uf054"     // Printable Rune : ""
 	Browser     = "\U000f0208" // Printable Rune : "󰈈"
 	Select      = "\U000f01bd" // Printable Rune : "󰆽"
+	Tree        = "\U000f0645" // Printable Rune : "󰙅"
+	Flatten     = "\U000f0279" // Printable Rune : "󰉹"
 	Error       = "\uf530"     // Printable Rune : ""
 	Warn        = "\uf071"     // Printable Rune : ""
 	Done        = "\uf4a4"     // Printable Rune : ""
@@ -40,6 +42,11 @@ var (
 	SortAsc     = "\uf0de"     // Printable Rune : ""
 	SortDesc    = "\uf0dd"     // Printable Rune : ""
 	Terminal    = "\ue795"     // Printable Rune : ""
+	History     = "\uf1da"     // Printable Rune : ""
+	Bookmark    = "\uf02e"     // Printable Rune : ""
+	GitBranch   = "\ue725"     // Printable Rune : ""
+	Tag         = "\uf02b"     // Printable Rune : ""
+	HideIgnored = "\U000f0209" // Printable Rune : "󰈉"
 )
 
 /*
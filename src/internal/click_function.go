@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+// Two clicks on the same cell within this interval are considered a double click
+const doubleClickInterval = 500 * time.Millisecond
+
+// Rows taken by the top border, path, divider and search bar of a file panel
+const filePanelElementStartRow = 4
+
+func clickMainAction(msg tea.MouseMsg, m *model) {
+	slog.Debug("clickMainAction called", "x", msg.X, "y", msg.Y,
+		"ctrl", msg.Ctrl, "shift", msg.Shift, "focusPanel", m.focusPanel)
+
+	doubleClick := m.lastClick.x == msg.X && m.lastClick.y == msg.Y &&
+		time.Since(m.lastClick.time) < doubleClickInterval
+	m.lastClick = mouseClickRecord{time: time.Now(), x: msg.X, y: msg.Y}
+
+	if m.fileModel.filePanels[m.filePanelFocusIndex].sortOptions.open {
+		m.sortOptionsClick(msg.X, msg.Y)
+		return
+	}
+
+	// Clicks are ignored while the user is typing or a modal is open
+	if m.isTypingOrModalOpen() {
+		return
+	}
+
+	if m.toggleFooter && msg.Y >= m.mainPanelHeight+2 {
+		m.footerClick(msg.X)
+		return
+	}
+
+	sidebarWidth := sidebarFullWidth()
+	if msg.X < sidebarWidth {
+		m.sidebarClick(msg.Y, doubleClick)
+		return
+	}
+
+	panelStartX := sidebarWidth
+	for i := range m.fileModel.filePanels {
+		if i == m.filePanelFocusIndex {
+			panelStartX += m.parentColumnFullWidth()
+			// Click on the parent column. Nothing to do
+			if msg.X < panelStartX {
+				return
+			}
+		}
+		panelEndX := panelStartX + m.filePanelWidth(i) + 2
+		if msg.X < panelEndX {
+			m.filePanelClick(i, msg.Y, doubleClick, msg.Ctrl, msg.Shift)
+			return
+		}
+		panelStartX = panelEndX
+	}
+	// Click on file preview panel. Nothing to do
+}
+
+// Whether a text input has focus or a modal is open. In this state
+// mouse clicks should not change focus or cursor
+func (m *model) isTypingOrModalOpen() bool {
+	panel := m.fileModel.filePanels[m.filePanelFocusIndex]
+	return firstUse || m.helpMenu.open || m.promptModal.IsOpen() ||
+		m.typingModal.open || m.warnModal.open || m.confirmToQuit || m.historyModal.IsOpen() || m.jumpModal.IsOpen() ||
+		m.marksModal.picker.IsOpen() || m.marksModal.renaming != "" || m.tagModal.picker.IsOpen() || m.tagFilterModal.IsOpen() ||
+		m.selectByModal.picker.IsOpen() || m.selectByModal.typing ||
+		m.fileModel.renaming || panel.searchBar.Focused() || panel.finder.textInput.Focused() ||
+		m.sidebarModel.IsRenaming() || m.sidebarModel.SearchBarFocused()
+}
+
+// Width of the rendered sidebar including its border
+func sidebarFullWidth() int {
+	if common.Config.SidebarWidth == 0 {
+		return 0
+	}
+	return common.Config.SidebarWidth + 2
+}
+
+// Move focus to the file panel at panelIndex
+func (m *model) focusFilePanel(panelIndex int) {
+	m.fileModel.filePanels[m.filePanelFocusIndex].focusType = noneFocus
+	m.filePanelFocusIndex = panelIndex
+	m.focusPanel = nonePanelFocus
+	m.fileModel.filePanels[m.filePanelFocusIndex].focusType = focus
+}
+
+// Handle click at row y of the file panel at panelIndex
+func (m *model) filePanelClick(panelIndex int, y int, doubleClick bool, ctrl bool, shift bool) {
+	m.focusFilePanel(panelIndex)
+	panel := &m.fileModel.filePanels[panelIndex]
+
+	row := y - filePanelElementStartRow
+	if row < 0 || row >= panelElementHeight(m.mainPanelHeight) {
+		return
+	}
+	clickedIndex := panel.render + row
+	if clickedIndex >= len(panel.element) {
+		return
+	}
+
+	if panel.panelMode == selectMode {
+		switch {
+		case ctrl:
+			panel.cursor = clickedIndex
+			panel.singleItemSelect()
+		case shift:
+			panel.rangeSelect(panel.cursor, clickedIndex)
+			panel.cursor = clickedIndex
+		default:
+			panel.cursor = clickedIndex
+		}
+	} else {
+		panel.cursor = clickedIndex
+	}
+
+	if doubleClick && !ctrl && !shift {
+		m.enterPanel()
+	}
+
+	m.fileMetaData.renderIndex = 0
+	go func() {
+		m.returnMetaData()
+	}()
+}
+
+// Select all items between index from and to (both inclusive) that are not
+// already selected
+func (panel *filePanel) rangeSelect(from int, to int) {
+	if from > to {
+		from, to = to, from
+	}
+	from = max(from, 0)
+	to = min(to, len(panel.element)-1)
+	for i := from; i <= to; i++ {
+		if !arrayContains(panel.selected, panel.element[i].location) {
+			panel.selected = append(panel.selected, panel.element[i].location)
+		}
+	}
+}
+
+// Handle click at row y of the sidebar. Double click opens the directory
+// in the focused file panel
+func (m *model) sidebarClick(y int, doubleClick bool) {
+	// Row 0 is the top border
+	clicked := m.sidebarModel.ClickDirectory(y-1, m.mainPanelHeight, m.focusPanel == sidebarFocus)
+	m.focusPanel = sidebarFocus
+	m.fileModel.filePanels[m.filePanelFocusIndex].focusType = secondFocus
+	if clicked && doubleClick {
+		m.sidebarSelectDirectory()
+	}
+}
+
+// Handle click on the footer at column x
+func (m *model) footerClick(x int) {
+	footerPanelWidth := utils.FooterWidth(m.fullWidth) + 2
+	switch x / footerPanelWidth {
+	case 0:
+		m.focusPanel = processBarFocus
+	case 1:
+		m.focusPanel = metadataFocus
+		go func() {
+			m.returnMetaData()
+		}()
+	default:
+		// Clipboard cannot be focused
+		return
+	}
+	m.fileModel.filePanels[m.filePanelFocusIndex].focusType = secondFocus
+}
+
+// Handle click while sort options menu is open. Clicking an option selects it,
+// clicking outside of the menu closes it
+func (m *model) sortOptionsClick(x int, y int) {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	overlayX, overlayY := m.sortOptionsOverlayPosition()
+	if x < overlayX || x >= overlayX+panel.sortOptions.width+2 {
+		m.cancelSortOptions()
+		return
+	}
+	// Top border, title and a blank line come before the options
+	option := y - overlayY - 3
+	if option < 0 || option >= len(panel.sortOptions.data.options) {
+		m.cancelSortOptions()
+		return
+	}
+	panel.sortOptions.cursor = option
+	m.confirmSortOptions()
+}
+
+func (m *model) sortOptionsOverlayPosition() (int, int) {
+	panel := m.fileModel.filePanels[m.filePanelFocusIndex]
+	return m.fullWidth/2 - panel.sortOptions.width/2, m.fullHeight/2 - panel.sortOptions.height/2
+}
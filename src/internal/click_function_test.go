@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func leftClickMsg(x int, y int) tea.MouseMsg {
+	return tea.MouseMsg{
+		X:      x,
+		Y:      y,
+		Action: tea.MouseActionPress,
+		Button: tea.MouseButtonLeft,
+	}
+}
+
+func Test_rangeSelect(t *testing.T) {
+	panel := filePanel{
+		element: []element{
+			{location: "/a"}, {location: "/b"}, {location: "/c"}, {location: "/d"},
+		},
+		selected: []string{"/b"},
+	}
+	panel.rangeSelect(3, 1)
+	assert.ElementsMatch(t, []string{"/b", "/c", "/d"}, panel.selected)
+
+	panel.rangeSelect(-1, 10)
+	assert.ElementsMatch(t, []string{"/a", "/b", "/c", "/d"}, panel.selected)
+}
+
+func TestModel_Update_Click(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0755))
+
+	setupModel := func(t *testing.T) model {
+		t.Helper()
+		m := defaultModelConfig(false, true, []string{dir})
+		firstUse = false
+		_, err := TeaUpdate(&m, tea.WindowSizeMsg{Width: 150, Height: 40})
+		require.NoError(t, err)
+		require.Len(t, m.fileModel.filePanels[0].element, 4)
+		return m
+	}
+	panelX := sidebarFullWidth() + 2
+
+	t.Run("Click moves cursor", func(t *testing.T) {
+		m := setupModel(t)
+		_, err := TeaUpdate(&m, leftClickMsg(panelX, filePanelElementStartRow+2))
+		require.NoError(t, err)
+		assert.Equal(t, 2, m.fileModel.filePanels[0].cursor)
+	})
+
+	t.Run("Double click enters directory", func(t *testing.T) {
+		m := setupModel(t)
+		// Directories are listed first
+		_, _ = TeaUpdate(&m, leftClickMsg(panelX, filePanelElementStartRow))
+		_, _ = TeaUpdate(&m, leftClickMsg(panelX, filePanelElementStartRow))
+		assert.Equal(t, filepath.Join(dir, "subdir"), m.fileModel.filePanels[0].location)
+	})
+
+	t.Run("Click on footer focuses processbar", func(t *testing.T) {
+		m := setupModel(t)
+		_, _ = TeaUpdate(&m, leftClickMsg(1, m.mainPanelHeight+3))
+		assert.Equal(t, processBarFocus, m.focusPanel)
+		assert.Equal(t, secondFocus, m.fileModel.filePanels[0].focusType)
+
+		_, _ = TeaUpdate(&m, leftClickMsg(panelX, filePanelElementStartRow))
+		assert.Equal(t, nonePanelFocus, m.focusPanel)
+		assert.Equal(t, focus, m.fileModel.filePanels[0].focusType)
+	})
+
+	t.Run("Ctrl and shift click select items in select mode", func(t *testing.T) {
+		m := setupModel(t)
+		m.fileModel.filePanels[0].panelMode = selectMode
+		ctrlClick := leftClickMsg(panelX, filePanelElementStartRow+1)
+		ctrlClick.Ctrl = true
+		_, _ = TeaUpdate(&m, ctrlClick)
+		assert.Equal(t, []string{filepath.Join(dir, "a.txt")}, m.fileModel.filePanels[0].selected)
+
+		shiftClick := leftClickMsg(panelX, filePanelElementStartRow+3)
+		shiftClick.Shift = true
+		_, _ = TeaUpdate(&m, shiftClick)
+		assert.Len(t, m.fileModel.filePanels[0].selected, 3)
+		assert.Equal(t, 3, m.fileModel.filePanels[0].cursor)
+	})
+}
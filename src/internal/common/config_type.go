@@ -65,19 +65,28 @@ type ThemeType struct {
 type ConfigType struct {
 	Theme string `toml:"theme" comment:"More details are at https://superfile.netlify.app/configure/superfile-config/\nchange your theme"`
 
-	Editor                 string `toml:"editor" comment:"\nThe editor files will be opened with. (Leave blank to use the EDITOR environment variable)."`
-	DirEditor              string `toml:"dir_editor" comment:"\nThe editor directories will be opened with. (Leave blank to use the default editors)."`
-	AutoCheckUpdate        bool   `toml:"auto_check_update" comment:"\nAuto check for update"`
-	CdOnQuit               bool   `toml:"cd_on_quit" comment:"\nCd on quit (For more details, please check out https://superfile.netlify.app/configure/superfile-config/#cd_on_quit)"`
-	DefaultOpenFilePreview bool   `toml:"default_open_file_preview" comment:"\nWhether to open file preview automatically every time superfile is opened."`
-	ShowImagePreview       bool   `toml:"show_image_preview" comment:"\nWhether to show image preview."`
-	DefaultDirectory       string `toml:"default_directory" comment:"\nThe path of the first file panel when superfile is opened."`
-	FileSizeUseSI          bool   `toml:"file_size_use_si" comment:"\nDisplay file sizes using powers of 1000 (kB, MB, GB) instead of powers of 1024 (KiB, MiB, GiB)."`
-	DefaultSortType        int    `toml:"default_sort_type" comment:"\nDefault sort type (0: Name, 1: Size, 2: Date Modified)."`
-	SortOrderReversed      bool   `toml:"sort_order_reversed" comment:"\nDefault sort order (false: Ascending, true: Descending)."`
-	CaseSensitiveSort      bool   `toml:"case_sensitive_sort" comment:"\nCase sensitive sort by name (captal \"B\" comes before \"a\" if true)."`
-	ShellCloseOnSuccess    bool   `toml:"shell_close_on_success" comment:"\nWhether to close the shell on successful command execution."`
-	Debug                  bool   `toml:"debug" comment:"\nWhether to enable debug mode."`
+	Editor                 string   `toml:"editor" comment:"\nThe editor files will be opened with. (Leave blank to use the EDITOR environment variable)."`
+	DirEditor              string   `toml:"dir_editor" comment:"\nThe editor directories will be opened with. (Leave blank to use the default editors)."`
+	AutoCheckUpdate        bool     `toml:"auto_check_update" comment:"\nAuto check for update"`
+	CdOnQuit               bool     `toml:"cd_on_quit" comment:"\nCd on quit (For more details, please check out https://superfile.netlify.app/configure/superfile-config/#cd_on_quit)"`
+	DefaultOpenFilePreview bool     `toml:"default_open_file_preview" comment:"\nWhether to open file preview automatically every time superfile is opened."`
+	DefaultMillerColumns   bool     `toml:"default_miller_columns" comment:"\nWhether to use the Miller columns layout, with the parent directory shown left of the focused file panel, every time superfile is opened."`
+	ShowImagePreview       bool     `toml:"show_image_preview" comment:"\nWhether to show image preview."`
+	DefaultDirectory       string   `toml:"default_directory" comment:"\nThe path of the first file panel when superfile is opened."`
+	SessionRestore         bool     `toml:"session_restore" comment:"\nSave the file panels layout on quit and restore it when superfile is opened without path arguments."`
+	FrecencyImportZoxide   bool     `toml:"frecency_import_zoxide" comment:"\nSeed the directory ranking used by the jump menu and the prompt cd command from zoxide's database, while superfile has no ranking yet."`
+	FileSizeUseSI          bool     `toml:"file_size_use_si" comment:"\nDisplay file sizes using powers of 1000 (kB, MB, GB) instead of powers of 1024 (KiB, MiB, GiB)."`
+	DefaultSortType        int      `toml:"default_sort_type" comment:"\nDefault sort type (0: Name, 1: Size, 2: Date Modified, 3: Natural, 4: Extension, 5: Type, 6: Date Created, 7: Date Accessed)."`
+	SortOrderReversed      bool     `toml:"sort_order_reversed" comment:"\nDefault sort order (false: Ascending, true: Descending)."`
+	SortDirectoriesFirst   bool     `toml:"sort_directories_first" comment:"\nList directories before files, whatever the sort type and order."`
+	CaseSensitiveSort      bool     `toml:"case_sensitive_sort" comment:"\nCase sensitive sort by name (captal \"B\" comes before \"a\" if true)."`
+	FlattenMaxDepth        int      `toml:"flatten_max_depth" comment:"\nHow many directory levels below the panel location are listed in flatten mode (0 for no limit)."`
+	DetailColumns          []string `toml:"detail_columns" comment:"\nColumns of the file panel detail view, in order. Values: \"size\", \"modified\", \"permissions\", \"owner\", \"extension\".\nThe last columns are hidden first when the panel is too narrow."`
+	DateFormat             string   `toml:"date_format" comment:"\nFormat of the modified time in the detail view, written as the reference time \"Mon Jan 2 15:04:05 2006\" would be displayed."`
+	GitStatus              bool     `toml:"git_status" comment:"\nShow the git status of items beside their names, and the branch in the top line of file panels inside git work trees."`
+	HiddenPatterns         []string `toml:"hidden_patterns" comment:"\nGlob patterns of item names hidden in file panels that hide ignored items, along with the items matched by .gitignore and .ignore files."`
+	ShellCloseOnSuccess    bool     `toml:"shell_close_on_success" comment:"\nWhether to close the shell on successful command execution."`
+	Debug                  bool     `toml:"debug" comment:"\nWhether to enable debug mode."`
 
 	Nerdfont              bool   `toml:"nerdfont" comment:"\n================   Style =================\n\n If you don't have or don't want Nerdfont installed you can turn this off"`
 	TransparentBackground bool   `toml:"transparent_background" comment:"\nSet transparent background or not (this only work when your terminal background is transparent)"`
@@ -114,8 +123,24 @@ type HotkeysType struct {
 	NextFilePanel          []string `toml:"next_file_panel"`
 	PreviousFilePanel      []string `toml:"previous_file_panel"`
 	ToggleFilePreviewPanel []string `toml:"toggle_file_preview_panel"`
+	ToggleMillerColumns    []string `toml:"toggle_miller_columns"`
 	OpenSortOptionsMenu    []string `toml:"open_sort_options_menu"`
 	ToggleReverseSort      []string `toml:"toggle_reverse_sort"`
+	ToggleDirectoriesFirst []string `toml:"toggle_directories_first"`
+
+	OpenNewTab  []string `toml:"open_new_tab" comment:"file panel tabs"`
+	CloseTab    []string `toml:"close_tab"`
+	NextTab     []string `toml:"next_tab"`
+	PreviousTab []string `toml:"previous_tab"`
+
+	HistoryBack     []string `toml:"history_back" comment:"location history"`
+	HistoryForward  []string `toml:"history_forward"`
+	OpenHistoryMenu []string `toml:"open_history_menu"`
+	OpenJumpMenu    []string `toml:"open_jump_menu"`
+
+	SetMark       []string `toml:"set_mark" comment:"marks"`
+	JumpToMark    []string `toml:"jump_to_mark"`
+	OpenMarksMenu []string `toml:"open_marks_menu"`
 
 	FocusOnProcessBar []string `toml:"focus_on_process_bar" comment:"change focus"`
 	FocusOnSidebar    []string `toml:"focus_on_sidebar"`
@@ -132,28 +157,48 @@ type HotkeysType struct {
 	ExtractFile  []string `toml:"extract_file" comment:"compress and extract"`
 	CompressFile []string `toml:"compress_file"`
 
+	GitStage   []string `toml:"git_stage" comment:"git"`
+	GitUnstage []string `toml:"git_unstage"`
+	GitDiscard []string `toml:"git_discard"`
+	GitDiff    []string `toml:"git_diff"`
+
+	OpenTagMenu []string `toml:"open_tag_menu" comment:"tags"`
+	FilterByTag []string `toml:"filter_by_tag"`
+
 	OpenFileWithEditor             []string `toml:"open_file_with_editor" comment:"editor"`
 	OpenCurrentDirectoryWithEditor []string `toml:"open_current_directory_with_editor"`
 
-	PinnedDirectory []string `toml:"pinned_directory" comment:"other"`
-	ToggleDotFile   []string `toml:"toggle_dot_file"`
-	ChangePanelMode []string `toml:"change_panel_mode"`
-	OpenHelpMenu    []string `toml:"open_help_menu"`
-	OpenCommandLine []string `toml:"open_command_line"`
-	OpenSPFPrompt   []string `toml:"open_spf_prompt"`
+	PinnedDirectory  []string `toml:"pinned_directory" comment:"other"`
+	ToggleDotFile    []string `toml:"toggle_dot_file"`
+	ToggleIgnored    []string `toml:"toggle_ignored"`
+	ChangePanelMode  []string `toml:"change_panel_mode"`
+	ToggleTreeView   []string `toml:"toggle_tree_view"`
+	ToggleFlatten    []string `toml:"toggle_flatten"`
+	ToggleDetailView []string `toml:"toggle_detail_view"`
+	OpenHelpMenu     []string `toml:"open_help_menu"`
+	OpenCommandLine  []string `toml:"open_command_line"`
+	OpenSPFPrompt    []string `toml:"open_spf_prompt"`
 
 	CopyPath []string `toml:"copy_path"`
 	CopyPWD  []string `toml:"copy_present_working_directory"`
 
 	ToggleFooter []string `toml:"toggle_footer"`
 
-	ConfirmTyping []string `toml:"confirm_typing" comment:"=================================================================================================\nTyping hotkeys (can conflict with all hotkeys)"`
-	CancelTyping  []string `toml:"cancel_typing"`
+	ConfirmTyping   []string `toml:"confirm_typing" comment:"=================================================================================================\nTyping hotkeys (can conflict with all hotkeys)"`
+	CancelTyping    []string `toml:"cancel_typing"`
+	NextSearchMode  []string `toml:"next_search_mode"`
+	ToggleSearchPin []string `toml:"toggle_search_pin"`
 
 	ParentDirectory []string `toml:"parent_directory" comment:"=================================================================================================\nNormal mode hotkeys (can conflict with other modes, cannot conflict with global hotkeys)"`
 	SearchBar       []string `toml:"search_bar"`
+	FindRecursively []string `toml:"find_recursively"`
+	SearchContent   []string `toml:"search_content"`
+	ToggleTreeNode  []string `toml:"toggle_tree_node"`
 
 	FilePanelSelectModeItemsSelectDown []string `toml:"file_panel_select_mode_items_select_down" comment:"=================================================================================================\nSelect mode hotkeys (can conflict with other modes, cananot conflict with global hotkeys)"`
 	FilePanelSelectModeItemsSelectUp   []string `toml:"file_panel_select_mode_items_select_up"`
 	FilePanelSelectAllItem             []string `toml:"file_panel_select_all_items"`
+	FilePanelSelectRange               []string `toml:"file_panel_select_range"`
+	FilePanelInvertSelection           []string `toml:"file_panel_invert_selection"`
+	FilePanelSelectBy                  []string `toml:"file_panel_select_by"`
 }
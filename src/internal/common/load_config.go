@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 
 	"github.com/pelletier/go-toml/v2"
 	"github.com/yorukot/superfile/src/internal/utils"
@@ -40,9 +41,25 @@ func ValidateConfig(c *ConfigType) error {
 		return errors.New(LoadConfigError("sidebar_width"))
 	}
 
-	if c.DefaultSortType < 0 || c.DefaultSortType > 2 {
+	if c.DefaultSortType < 0 || c.DefaultSortType >= len(SortTypeNames()) {
 		return errors.New(LoadConfigError("default_sort_type"))
 	}
+
+	if c.FlattenMaxDepth < 0 {
+		return errors.New(LoadConfigError("flatten_max_depth"))
+	}
+
+	for _, column := range c.DetailColumns {
+		if !slices.Contains([]string{SizeColumn, ModifiedColumn, PermissionsColumn, OwnerColumn, ExtensionColumn}, column) {
+			return errors.New(LoadConfigError("detail_columns"))
+		}
+	}
+
+	for _, pattern := range c.HiddenPatterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return errors.New(LoadConfigError("hidden_patterns"))
+		}
+	}
 	return nil
 }
 
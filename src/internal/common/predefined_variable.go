@@ -11,6 +11,33 @@ import (
 const WheelRunTime = 5
 const DefaultCommandTimeout = 5000 * time.Millisecond
 
+// Columns of the file panel detail view
+const (
+	SizeColumn        = "size"
+	ModifiedColumn    = "modified"
+	PermissionsColumn = "permissions"
+	OwnerColumn       = "owner"
+	ExtensionColumn   = "extension"
+)
+
+// Sort types of the file panels, in the order of the sort options menu.
+// default_sort_type is one of them
+const (
+	SortByName = iota
+	SortBySize
+	SortByDateModified
+	SortByNatural
+	SortByExtension
+	SortByType
+	SortByDateCreated
+	SortByDateAccessed
+)
+
+// Names of the sort types shown in the sort options menu, by sort type
+func SortTypeNames() []string {
+	return []string{"Name", "Size", "Date Modified", "Natural", "Extension", "Type", "Date Created", "Date Accessed"}
+}
+
 var (
 	MinimumHeight = 24
 	MinimumWidth  = 60
@@ -26,6 +53,7 @@ var (
 	SideBarSuperfileTitle string
 	SideBarPinnedDivider  string
 	SideBarDisksDivider   string
+	SideBarTaggedDivider  string
 	SideBarNoneText       string
 	LipglossError         string
 )
@@ -50,5 +78,8 @@ func LoadPrerenderedVariables() {
 	SideBarDisksDivider = SidebarTitleStyle.Render("󱇰 Disks") + SidebarDividerStyle.Render(" ────────────") + "\n"
 	SideBarDisksDivider = ansi.Truncate(SideBarDisksDivider, Config.SidebarWidth, "")
 
+	SideBarTaggedDivider = SidebarTitleStyle.Render(" Tagged") + SidebarDividerStyle.Render(" ───────────") + "\n"
+	SideBarTaggedDivider = ansi.Truncate(SideBarTaggedDivider, Config.SidebarWidth, "")
+
 	SideBarNoneText = SidebarStyle.Render(" " + icon.Error + " None")
 }
@@ -2,6 +2,7 @@ package common
 
 import (
 	"bufio"
+	"cmp"
 	"errors"
 	"fmt"
 	"io"
@@ -57,19 +58,56 @@ func TruncateMiddleText(text string, maxChars int, tails string) string {
 	return truncatedText
 }
 
-func PrettierName(name string, width int, isDir bool, isSelected bool, bgColor lipgloss.Color) string {
+// Truncate text to width and render it with style, underlining the runes at
+// the given indexes, e.g. the ones matched by a fuzzy search
+func RenderMatchedText(text string, width int, positions []int, style lipgloss.Style) string {
+	truncatedText := TruncateText(text, width, "...")
+	if len(positions) == 0 {
+		return style.Render(truncatedText)
+	}
+	runes := []rune(truncatedText)
+	// Runes of the tail added by the truncation can't be matched
+	matchableCount := len(runes)
+	if truncatedText != text {
+		matchableCount -= len("...")
+	}
+	matched := make([]bool, len(runes))
+	for _, pos := range positions {
+		if pos >= 0 && pos < matchableCount {
+			matched[pos] = true
+		}
+	}
+
+	matchStyle := style.Bold(true).Underline(true)
+	var result strings.Builder
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && matched[i] == matched[start] {
+			continue
+		}
+		if matched[start] {
+			result.WriteString(matchStyle.Render(string(runes[start:i])))
+		} else {
+			result.WriteString(style.Render(string(runes[start:i])))
+		}
+		start = i
+	}
+	return result.String()
+}
+
+func PrettierName(name string, width int, isDir bool, isSelected bool, bgColor lipgloss.Color,
+	matchPositions []int) string {
 	style := GetElementIcon(name, isDir, Config.Nerdfont)
 	if isSelected {
 		return StringColorRender(lipgloss.Color(style.Color), bgColor).
 			Background(bgColor).
 			Render(style.Icon+" ") +
-			FilePanelItemSelectedStyle.
-				Render(TruncateText(name, width, "..."))
+			RenderMatchedText(name, width, matchPositions, FilePanelItemSelectedStyle)
 	}
 	return StringColorRender(lipgloss.Color(style.Color), bgColor).
 		Background(bgColor).
 		Render(style.Icon+" ") +
-		FilePanelStyle.Render(TruncateText(name, width, "..."))
+		RenderMatchedText(name, width, matchPositions, FilePanelStyle)
 }
 
 func PrettierDirectoryPreviewName(name string, isDir bool, bgColor lipgloss.Color) string {
@@ -105,6 +143,46 @@ func FileNameWithoutExtension(fileName string) string {
 	return fileName
 }
 
+// NaturalCompare compares a and b like strings.Compare, except that runs of
+// digits are compared by their value, so that "file2" comes before "file10"
+func NaturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		aDigits, bDigits := digitPrefixLength(a), digitPrefixLength(b)
+		if aDigits > 0 && bDigits > 0 {
+			aNumber, bNumber := strings.TrimLeft(a[:aDigits], "0"), strings.TrimLeft(b[:bDigits], "0")
+			// Without leading zeros, longer numbers are larger
+			if c := cmp.Compare(len(aNumber), len(bNumber)); c != 0 {
+				return c
+			}
+			if c := strings.Compare(aNumber, bNumber); c != 0 {
+				return c
+			}
+			// Same value, the one with fewer leading zeros comes first
+			if c := cmp.Compare(aDigits, bDigits); c != 0 {
+				return c
+			}
+			a, b = a[aDigits:], b[bDigits:]
+			continue
+		}
+		aRune, aSize := utf8.DecodeRuneInString(a)
+		bRune, bSize := utf8.DecodeRuneInString(b)
+		if aRune != bRune {
+			return cmp.Compare(aRune, bRune)
+		}
+		a, b = a[aSize:], b[bSize:]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// Number of ASCII digits at the start of s
+func digitPrefixLength(s string) int {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
 func FormatFileSize(size int64) string {
 	if size == 0 {
 		return "0B"
@@ -2,7 +2,13 @@ package common
 
 import (
 	"fmt"
+	"io"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/term/ansi"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestStringTruncate(t *testing.T) {
@@ -36,6 +42,39 @@ func TestStringTruncate(t *testing.T) {
 	}
 }
 
+func TestRenderMatchedText(t *testing.T) {
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenv.ANSI)
+	style := renderer.NewStyle()
+	matchStyle := style.Bold(true).Underline(true)
+
+	testdata := []struct {
+		name      string
+		text      string
+		width     int
+		positions []int
+		expected  string
+	}{
+		{"No match", "Hello world", 20, nil, style.Render("Hello world")},
+		{"Separate matches", "Hello world", 20, []int{4, 0, 6},
+			matchStyle.Render("H") + style.Render("ell") + matchStyle.Render("o") +
+				style.Render(" ") + matchStyle.Render("w") + style.Render("orld")},
+		{"Adjacent matches", "Hello", 20, []int{1, 2},
+			style.Render("H") + matchStyle.Render("el") + style.Render("lo")},
+		{"Matches in truncated part are dropped", "Hello world", 8, []int{4, 5, 9},
+			style.Render("Hell") + matchStyle.Render("o") + style.Render("...")},
+		{"Non ASCII text", "日本語.txt", 20, []int{1},
+			style.Render("日") + matchStyle.Render("本") + style.Render("語.txt")},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RenderMatchedText(tt.text, tt.width, tt.positions, style)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, TruncateText(tt.text, tt.width, "..."), ansi.Strip(result))
+		})
+	}
+}
+
 func TestFilenameWithouText(t *testing.T) {
 	var inputs = []struct {
 		input    string
@@ -58,6 +97,30 @@ func TestFilenameWithouText(t *testing.T) {
 	}
 }
 
+func TestNaturalCompare(t *testing.T) {
+	var inputs = []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"file2", "file10", -1},
+		{"file10", "file2", 1},
+		{"file10", "file10", 0},
+		{"file", "file1", -1},
+		{"a100b", "a100c", -1},
+		{"v1.10.0", "v1.9.2", 1},
+		{"file02", "file2", 1},
+		{"file02", "file3", -1},
+		{"10", "9a", 1},
+		{"élan", "zoo", 1},
+	}
+	for _, tt := range inputs {
+		t.Run(fmt.Sprintf("Comparing %q with %q", tt.a, tt.b), func(t *testing.T) {
+			assert.Equal(t, tt.expected, NaturalCompare(tt.a, tt.b))
+		})
+	}
+}
+
 func TestIsBufferPrintable(t *testing.T) {
 	var inputs = []struct {
 		input    string
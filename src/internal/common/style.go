@@ -38,6 +38,17 @@ var (
 	FilePanelItemSelectedStyle     lipgloss.Style
 )
 
+var (
+	GitBranchStyle     lipgloss.Style
+	GitModifiedStyle   lipgloss.Style
+	GitStagedStyle     lipgloss.Style
+	GitUntrackedStyle  lipgloss.Style
+	GitIgnoredStyle    lipgloss.Style
+	GitConflictedStyle lipgloss.Style
+
+	TagStyle lipgloss.Style
+)
+
 var (
 	ProcessErrorStyle       lipgloss.Style
 	ProcessInOperationStyle lipgloss.Style
@@ -189,6 +200,17 @@ func LoadThemeConfig() {
 	FilePanelTopPathStyle = lipgloss.NewStyle().Foreground(filePanelTopPathColor).Background(FilePanelBGColor)
 	FilePanelItemSelectedStyle = lipgloss.NewStyle().Foreground(filePanelItemSelectedFGColor).Background(filePanelItemSelectedBGColor)
 
+	// Git Status Style
+	GitBranchStyle = lipgloss.NewStyle().Foreground(filePanelTopDirectoryIconColor).Background(FilePanelBGColor)
+	GitModifiedStyle = lipgloss.NewStyle().Foreground(hintColor).Background(FilePanelBGColor)
+	GitStagedStyle = lipgloss.NewStyle().Foreground(correctColor).Background(FilePanelBGColor)
+	GitUntrackedStyle = lipgloss.NewStyle().Foreground(cancelColor).Background(FilePanelBGColor)
+	GitIgnoredStyle = lipgloss.NewStyle().Foreground(FilePanelBorderColor).Background(FilePanelBGColor)
+	GitConflictedStyle = lipgloss.NewStyle().Foreground(errorColor).Background(FilePanelBGColor)
+
+	// Tag Style
+	TagStyle = lipgloss.NewStyle().Foreground(hintColor).Background(FilePanelBGColor)
+
 	// Sidebar Special Style
 	SidebarDividerStyle = lipgloss.NewStyle().Foreground(sidebarDividerColor).Background(SidebarBGColor)
 	SidebarTitleStyle = lipgloss.NewStyle().Foreground(sidebarTitleColor).Background(SidebarBGColor)
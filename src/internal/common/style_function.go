@@ -2,6 +2,7 @@ package common
 
 import (
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/progress"
@@ -10,9 +11,12 @@ import (
 	"github.com/yorukot/superfile/src/config/icon"
 )
 
-// Generate border style for file panel
-func FilePanelBorderStyle(height int, width int, filePanelFocussed bool, borderBottom string) lipgloss.Style {
+// Generate border style for file panel. Empty borderTop keeps the default top border
+func FilePanelBorderStyle(height int, width int, filePanelFocussed bool, borderTop string, borderBottom string) lipgloss.Style {
 	border := GenerateBorder()
+	if borderTop != "" {
+		border.Top = borderTop
+	}
 	border.Left = ""
 	border.Right = ""
 
@@ -181,6 +185,21 @@ func SortOptionsModalBorderStyle(height int, width int, borderBottom string) lip
 		Foreground(ModalFGColor)
 }
 
+// Generate picker modal border style
+func PickerModalBorderStyle(height int, width int, borderBottom string) lipgloss.Style {
+	border := GenerateBorder()
+	border.Bottom = borderBottom
+
+	return lipgloss.NewStyle().
+		Border(border).
+		BorderForeground(ModalBorderActiveColor).
+		BorderBackground(ModalBGColor).
+		Width(width).
+		Height(height).
+		Background(ModalBGColor).
+		Foreground(ModalFGColor)
+}
+
 // Generate full screen style for terminal size too small etc
 func FullScreenStyle(height int, width int) lipgloss.Style {
 	return lipgloss.NewStyle().
@@ -252,6 +271,12 @@ func GenerateSearchBar() textinput.Model {
 	return ti
 }
 
+func GenerateFindBar() textinput.Model {
+	ti := GenerateSearchBar()
+	ti.Placeholder = "Find in subdirectories"
+	return ti
+}
+
 func GeneratePromptTextInput() textinput.Model {
 	t := textinput.New()
 	t.Prompt = ""
@@ -331,3 +356,29 @@ func GenerateFooterBorder(countString string, width int) string {
 	}
 	return strings.Repeat(Config.BorderBottom, repeatCount) + Config.BorderMiddleRight + countString + Config.BorderMiddleLeft
 }
+
+// Generate top border of file panel containing a tab strip. The active tab is
+// wrapped in brackets and titles are truncated so that all the tabs fit in width
+func GenerateTabBorder(titles []string, activeTab int, width int) string {
+	// The strip starts with one top border character. Each tab takes two border
+	// characters and two characters around its title
+	maxTitleWidth := (width-1)/len(titles) - 4
+
+	var border strings.Builder
+	border.WriteString(Config.BorderTop)
+	for i, title := range titles {
+		if maxTitleWidth < 4 {
+			title = strconv.Itoa(i + 1)
+		} else {
+			title = TruncateText(title, maxTitleWidth, "...")
+		}
+		if i == activeTab {
+			title = "[" + title + "]"
+		} else {
+			title = " " + title + " "
+		}
+		border.WriteString(Config.BorderMiddleRight + title + Config.BorderMiddleLeft)
+	}
+	border.WriteString(strings.Repeat(Config.BorderTop, width))
+	return border.String()
+}
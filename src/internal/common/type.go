@@ -1,5 +1,7 @@
 package common
 
+import "strings"
+
 // Placeholder inteface for now, might later move 'model' type to commons and have
 // and add an execute(model) function to this
 type ModelAction interface {
@@ -29,11 +31,17 @@ func (s SplitPanelAction) String() string {
 	return "SplitPanelAction"
 }
 
+// Either Location or Keywords is set. Keywords are resolved to a directory
+// via the frecency ranking
 type CDCurrentPanelAction struct {
 	Location string
+	Keywords []string
 }
 
 func (c CDCurrentPanelAction) String() string {
+	if len(c.Keywords) > 0 {
+		return "CDCurrentPanelAction to best match of " + strings.Join(c.Keywords, " ")
+	}
 	return "CDCurrentPanelAction to " + c.Location
 }
 
@@ -5,7 +5,9 @@ import (
 
 	"github.com/yorukot/superfile/src/internal/ui/sidebar"
 
+	"github.com/yorukot/superfile/src/config/icon"
 	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/picker"
 	"github.com/yorukot/superfile/src/internal/ui/prompt"
 )
 
@@ -19,13 +21,14 @@ func defaultModelConfig(toggleDotFile bool, toggleFooter bool, firstFilePanelDir
 			cursor:  0,
 			render:  0,
 		},
-		sidebarModel: sidebar.New(),
+		sidebarModel: sidebar.New(nil),
 		fileModel: fileModel{
 			filePanels: filePanelSlice(firstFilePanelDirs),
 			filePreview: filePreviewPanel{
 				open: common.Config.DefaultOpenFilePreview,
 			},
-			width: 10,
+			millerColumns: common.Config.DefaultMillerColumns,
+			width:         10,
 		},
 		helpMenu: helpMenuModal{
 			renderIndex: 0,
@@ -33,7 +36,19 @@ func defaultModelConfig(toggleDotFile bool, toggleFooter bool, firstFilePanelDir
 			data:        getHelpMenuData(),
 			open:        false,
 		},
-		promptModal:   prompt.DefaultModel(),
+		promptModal:  prompt.DefaultModel(),
+		historyModal: picker.New(icon.History + icon.Space + "History"),
+		jumpModal:    picker.NewFilterable(icon.Directory + icon.Space + "Jump to directory"),
+		marksModal: marksModal{
+			picker: picker.New(icon.Bookmark + icon.Space + "Marks"),
+		},
+		tagModal: tagModal{
+			picker: picker.NewFilterable(icon.Tag + icon.Space + "Tags"),
+		},
+		tagFilterModal: picker.New(icon.Tag + icon.Space + "Filter by tag"),
+		selectByModal: selectByModal{
+			picker: picker.New(icon.Select + icon.Space + "Select by"),
+		},
 		toggleDotFile: toggleDotFile,
 		toggleFooter:  toggleFooter,
 	}
@@ -93,6 +108,11 @@ func getHelpMenuData() []helpMenuModalData {
 			description:    "Toggle file preview panel",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ToggleMillerColumns,
+			description:    "Toggle Miller columns layout, showing the parent directory",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.OpenSortOptionsMenu,
 			description:    "Open sort options menu",
@@ -103,6 +123,11 @@ func getHelpMenuData() []helpMenuModalData {
 			description:    "Toggle reverse sort",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ToggleDirectoriesFirst,
+			description:    "Toggle listing directories before files",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.ToggleFooter,
 			description:    "Toggle footer",
@@ -118,6 +143,61 @@ func getHelpMenuData() []helpMenuModalData {
 			description:    "Focus on the previous file panel",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.OpenNewTab,
+			description:    "Open a new tab in the file panel",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.CloseTab,
+			description:    "Close the current tab",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.NextTab,
+			description:    "Switch to the next tab",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.PreviousTab,
+			description:    "Switch to the previous tab",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.HistoryBack,
+			description:    "Go back in the location history",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.HistoryForward,
+			description:    "Go forward in the location history",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.OpenHistoryMenu,
+			description:    "Open location history",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.OpenJumpMenu,
+			description:    "Jump to a frequently visited directory",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.SetMark,
+			description:    "Set a mark on the current directory or file, followed by a letter",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.JumpToMark,
+			description:    "Jump to a mark, followed by its letter",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.OpenMarksMenu,
+			description:    "Open marks list",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.FocusOnProcessBar,
 			description:    "Focus on the processbar panel",
@@ -151,6 +231,11 @@ func getHelpMenuData() []helpMenuModalData {
 			description:    "Return to parent folder",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ToggleTreeNode,
+			description:    "Expand or collapse the directory in tree view",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.FilePanelSelectAllItem,
 			description:    "Select all items in focused file panel",
@@ -166,21 +251,76 @@ func getHelpMenuData() []helpMenuModalData {
 			description:    "Select down with your course",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.FilePanelSelectRange,
+			description:    "Start or stop selecting a range from the cursor",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.FilePanelInvertSelection,
+			description:    "Invert selection",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.FilePanelSelectBy,
+			description:    "Select items by name pattern, type or date",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.ToggleDotFile,
 			description:    "Toggle dot file display",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ToggleIgnored,
+			description:    "Toggle hiding ignored items",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.SearchBar,
 			description:    "Toggle active search bar",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.FindRecursively,
+			description:    "Find files in subdirectories",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.SearchContent,
+			description:    "Search file contents in subdirectories",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.NextSearchMode,
+			description:    "Switch search mode (fuzzy, glob, regex, exact) while typing",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ToggleSearchPin,
+			description:    "Keep search filter when entering subdirectories",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.ChangePanelMode,
 			description:    "Change between selection mode or normal mode",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ToggleTreeView,
+			description:    "Toggle tree view",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ToggleFlatten,
+			description:    "Toggle flatten mode, listing all items below the directory",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ToggleDetailView,
+			description:    "Toggle detail view with size, date and permission columns",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.PinnedDirectory,
 			description:    "Pin or Unpin folder to sidebar (can be auto saved)",
@@ -234,6 +374,36 @@ func getHelpMenuData() []helpMenuModalData {
 			description:    "Zip file or folder to .zip file",
 			hotkeyWorkType: normalType,
 		},
+		{
+			hotkey:         common.Hotkeys.GitStage,
+			description:    "Stage the changes of items in git",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.GitUnstage,
+			description:    "Unstage the changes of items in git",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.GitDiscard,
+			description:    "Discard the unstaged changes of items in git",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.GitDiff,
+			description:    "Preview the git diff of items",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.OpenTagMenu,
+			description:    "Add or remove tags of items",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.FilterByTag,
+			description:    "Filter items by tag, or clear the filter",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.OpenFileWithEditor,
 			description:    "Open file with your default editor",
@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+// Columns of the detail view are hidden when less width would be left for
+// the item names
+const minDetailNameWidth = 20
+
+// Used when date_format is not set
+const defaultDetailDateFormat = "2006-01-02 15:04"
+
+// Column of the detail view, with the cells of the rendered items
+type detailColumn struct {
+	cells []string
+	width int
+	// Sizes are right aligned, so that the units line up
+	rightAligned bool
+}
+
+// Text of the given detail view column for item. Cells are empty when the
+// stats of the item are unknown. Directory sizes are read from sizes
+func detailCell(column string, item element, sizes *dirsize.Cache) string {
+	if item.info == nil {
+		return ""
+	}
+	switch column {
+	case common.SizeColumn:
+		if !item.directory {
+			return common.FormatFileSize(item.info.Size())
+		}
+		if size, ok := sizes.Size(item.location, item.info.ModTime()); ok {
+			return common.FormatFileSize(size)
+		}
+		return "..."
+	case common.ModifiedColumn:
+		if common.Config.DateFormat == "" {
+			return item.info.ModTime().Format(defaultDetailDateFormat)
+		}
+		return item.info.ModTime().Format(common.Config.DateFormat)
+	case common.PermissionsColumn:
+		return item.info.Mode().String()
+	case common.OwnerColumn:
+		return utils.FileOwner(item.info)
+	case common.ExtensionColumn:
+		if item.directory {
+			return ""
+		}
+		return strings.TrimPrefix(filepath.Ext(item.location), ".")
+	}
+	return ""
+}
+
+// Detail view columns named names for items that fit in width, along with
+// the item names. Columns are dropped from the end as width shrinks
+func detailColumns(names []string, items []element, width int, sizes *dirsize.Cache) []detailColumn {
+	// Names are indented in tree view, by the deepest indent of the rows
+	prefixWidth := 0
+	for _, item := range items {
+		prefixWidth = max(prefixWidth, lipgloss.Width(item.treePrefix))
+	}
+	width -= prefixWidth
+	var columns []detailColumn
+	for _, name := range names {
+		column := detailColumn{
+			cells:        make([]string, len(items)),
+			rightAligned: name == common.SizeColumn,
+		}
+		for i, item := range items {
+			column.cells[i] = detailCell(name, item, sizes)
+			column.width = max(column.width, lipgloss.Width(column.cells[i]))
+		}
+		if column.width == 0 {
+			continue
+		}
+		// Columns are separated by a space
+		width -= column.width + 1
+		if width < minDetailNameWidth {
+			break
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// Total width of columns, including their separators
+func detailColumnsWidth(columns []detailColumn) int {
+	width := 0
+	for _, column := range columns {
+		width += column.width + 1
+	}
+	return width
+}
+
+// Cells of the row at index i of columns, each preceded by a separator
+func renderDetailRow(columns []detailColumn, i int) string {
+	var row strings.Builder
+	for _, column := range columns {
+		padding := strings.Repeat(" ", column.width-lipgloss.Width(column.cells[i]))
+		if column.rightAligned {
+			row.WriteString(" " + padding + column.cells[i])
+		} else {
+			row.WriteString(" " + column.cells[i] + padding)
+		}
+	}
+	return common.FilePanelStyle.Render(row.String())
+}
+
+// Names of the columns shown by the panel. Sizes are shown when sorting by
+// size, even without the detail view
+func (panel *filePanel) detailColumnNames() []string {
+	if panel.detailView {
+		return common.Config.DetailColumns
+	}
+	if panel.sortOptions.data.selected == common.SortBySize {
+		return []string{common.SizeColumn}
+	}
+	return nil
+}
+
+func (m *model) toggleDetailView() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.detailView = !panel.detailView
+}
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+func setDetailConfig(t *testing.T, columns []string, dateFormat string) {
+	t.Helper()
+	prevColumns, prevFormat := common.Config.DetailColumns, common.Config.DateFormat
+	common.Config.DetailColumns, common.Config.DateFormat = columns, dateFormat
+	t.Cleanup(func() {
+		common.Config.DetailColumns, common.Config.DateFormat = prevColumns, prevFormat
+	})
+}
+
+func Test_detailCell(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(file, make([]byte, 1000), 0644))
+	modTime := time.Date(2024, time.March, 14, 9, 26, 0, 0, time.Local)
+	require.NoError(t, os.Chtimes(file, modTime, modTime))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	setDetailConfig(t, nil, "Jan _2 15:04")
+
+	sizes := dirsize.New(dirSizeWorkers, nil)
+	elements := returnDirElement(dir, false, defaultFilePanel(dir).sortOptions.data, nil)
+	require.Len(t, elements, 2)
+	subDir, notes := elements[0], elements[1]
+	require.NotNil(t, notes.info)
+
+	assert.Equal(t, common.FormatFileSize(1000), detailCell(common.SizeColumn, notes, sizes))
+	// Directory sizes are computed in the background
+	assert.Equal(t, "...", detailCell(common.SizeColumn, subDir, sizes))
+	assert.Eventually(t, func() bool {
+		return detailCell(common.SizeColumn, subDir, sizes) == common.FormatFileSize(0)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "Mar 14 09:26", detailCell(common.ModifiedColumn, notes, sizes))
+	assert.Equal(t, "txt", detailCell(common.ExtensionColumn, notes, sizes))
+	assert.Empty(t, detailCell(common.ExtensionColumn, subDir, sizes))
+	assert.Equal(t, notes.info.Mode().String(), detailCell(common.PermissionsColumn, notes, sizes))
+	if runtime.GOOS != "windows" {
+		assert.NotEmpty(t, utils.FileOwner(notes.info))
+	}
+
+	common.Config.DateFormat = ""
+	assert.Equal(t, "2024-03-14 09:26", detailCell(common.ModifiedColumn, notes, sizes))
+
+	// Stats of find results are unknown
+	assert.Empty(t, detailCell(common.SizeColumn, element{name: "x", location: file}, sizes))
+}
+
+func Test_detailColumns(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b"), nil, 0644))
+	setDetailConfig(t, []string{common.SizeColumn, common.ExtensionColumn, common.PermissionsColumn}, "")
+	elements := returnDirElement(dir, false, defaultFilePanel(dir).sortOptions.data, nil)
+
+	names := common.Config.DetailColumns
+	columns := detailColumns(names, elements, 100, nil)
+	require.Len(t, columns, 3)
+	assert.Equal(t, []string{"txt", ""}, columns[1].cells)
+	assert.Equal(t, columns[0].width+columns[1].width+columns[2].width+3, detailColumnsWidth(columns))
+
+	// The last columns are dropped first when the panel is narrow
+	narrow := detailColumns(names, elements, minDetailNameWidth+columns[0].width+columns[1].width+2, nil)
+	assert.Len(t, narrow, 2)
+	assert.Empty(t, detailColumns(names, elements, minDetailNameWidth, nil))
+
+	// Only the deepest tree indent takes space from the columns
+	fullWidth := minDetailNameWidth + detailColumnsWidth(columns)
+	indented := []element{elements[0], elements[1], elements[0], elements[1]}
+	for i, prefix := range []string{"├── ", "│   ├── ", "│   └── ", "└── "} {
+		indented[i].treePrefix = prefix
+	}
+	assert.Len(t, detailColumns(names, indented, fullWidth+8, nil), 3)
+	assert.Len(t, detailColumns(names, indented, fullWidth+7, nil), 2)
+
+	// Columns without any value take no space
+	elements[0].info, elements[1].info = nil, nil
+	assert.Empty(t, detailColumns(names, elements, 100, nil))
+}
+
+func TestModel_DetailView(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file.txt"), nil, 0644))
+	setDetailConfig(t, []string{common.PermissionsColumn}, "")
+	m := defaultModelConfig(false, true, []string{dir})
+	m.getFilePanelItems()
+
+	m.mainKey(common.Hotkeys.ToggleDetailView[0], nil)
+	assert.True(t, m.fileModel.filePanels[0].detailView)
+	m.mainKey(common.Hotkeys.ToggleDetailView[0], nil)
+	assert.False(t, m.fileModel.filePanels[0].detailView)
+}
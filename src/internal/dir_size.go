@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+const (
+	// Directories walked at the same time to compute their size
+	dirSizeWorkers = 4
+	// Shown in place of the size of a directory until it comes in
+	dirSizeCalculating = "Calculating..."
+)
+
+func notifyDirSizes() {
+	channel <- channelMessage{messageType: sendDirSizes}
+}
+
+// Sort again the panels sorted by size that list the directories whose size
+// came in, and fill in the size of the metadata
+func (m *model) handleDirSizes(paths []string) {
+	for i := range m.fileModel.filePanels {
+		panel := &m.fileModel.filePanels[i]
+		if panel.finder.active || panel.sortOptions.data.selected != common.SortBySize ||
+			panel.cursor >= len(panel.element) {
+			continue
+		}
+		if slices.ContainsFunc(paths, func(path string) bool { return isBelow(panel.location, path) }) {
+			// The cursor stays on the same item
+			m.reloadPanelElements(panel, panel.element[panel.cursor].location)
+		}
+	}
+	m.updateMetadataDirSize(paths)
+	m.markItemsSizesStale()
+}
+
+// Whether the panel computes the size of the directories it lists, to sort
+// them or show it in a detail view column
+func (panel *filePanel) needsDirSizes() bool {
+	return slices.Contains(panel.detailColumnNames(), common.SizeColumn)
+}
+
+// Whether path is below the directory dir
+func isBelow(dir string, path string) bool {
+	relPath, err := filepath.Rel(dir, path)
+	return err == nil && relPath != "." && !strings.HasPrefix(relPath, "..")
+}
+
+// Replace the size row of the metadata of the directory under the cursor if
+// it was waiting for one of paths
+func (m *model) updateMetadataDirSize(paths []string) {
+	panel := m.fileModel.filePanels[m.filePanelFocusIndex]
+	if panel.cursor >= len(panel.element) {
+		return
+	}
+	item := panel.element[panel.cursor]
+	if !item.directory || item.info == nil || !slices.Contains(paths, item.location) {
+		return
+	}
+	size, ok := m.dirSizes.Size(item.location, item.info.ModTime())
+	if !ok {
+		return
+	}
+	metadata := m.fileMetaData.metaData
+	if !slices.Contains(metadata, [2]string{"Name", filepath.Base(item.location)}) {
+		return
+	}
+	for i, row := range metadata {
+		if row == [2]string{"Size", dirSizeCalculating} {
+			metadata[i][1] = common.FormatFileSize(size)
+		}
+	}
+}
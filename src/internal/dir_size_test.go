@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+)
+
+func TestModel_DirSizes(t *testing.T) {
+	dir := t.TempDir()
+	for name, size := range map[string]int{"big/file": 3000, "small/file": 10, "medium/nested/file": 500} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), make([]byte, size), 0644))
+	}
+	m := defaultModelConfig(false, true, []string{dir})
+	// Computed sizes are polled with TakeComputed, tests reading the channel
+	// expect their own messages only
+	m.dirSizes = dirsize.New(dirSizeWorkers, nil)
+	panel := &m.fileModel.filePanels[0]
+	panel.sortOptions.data.selected = common.SortBySize
+	panel.focusType = focus
+	m.getFilePanelItems()
+	require.Len(t, panel.element, 3)
+	panel.cursor = 2
+	cursorLocation := panel.element[2].location
+
+	// Sizes come in the background, then the panel is sorted again
+	var computed []string
+	require.Eventually(t, func() bool {
+		for _, path := range m.dirSizes.TakeComputed() {
+			if isBelow(dir, path) {
+				computed = append(computed, path)
+			}
+		}
+		return len(computed) == 3
+	}, time.Second, 10*time.Millisecond)
+	m.handleDirSizes(computed)
+	var names []string
+	for _, item := range panel.element {
+		names = append(names, item.name)
+	}
+	assert.Equal(t, []string{"small", "medium", "big"}, names)
+	assert.Equal(t, cursorLocation, panel.element[panel.cursor].location, "the cursor stays on the same item")
+
+	// The metadata size row waiting for the directory is filled in
+	m.fileMetaData.metaData = [][2]string{{"Name", "big"}, {"Size", dirSizeCalculating}}
+	panel.cursor = 2
+	m.updateMetadataDirSize([]string{filepath.Join(dir, "big")})
+	assert.Equal(t, [2]string{"Size", common.FormatFileSize(3000)}, m.fileMetaData.metaData[1])
+}
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"log/slog"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsort"
+)
+
+// Sort type and order of the config, used for directories without saved
+// settings
+func defaultDirectorySort() dirsort.Setting {
+	return dirsort.Setting{
+		SortType: common.Config.DefaultSortType,
+		Reversed: common.Config.SortOrderReversed,
+	}
+}
+
+// Sort the panel with the settings saved for its location, or the defaults
+func (m *model) applyDirectorySort(panel *filePanel) {
+	setting, ok := m.dirSorts.Get(panel.location)
+	// The file could have been edited by hand
+	if !ok || setting.SortType < 0 || setting.SortType >= len(common.SortTypeNames()) {
+		setting = defaultDirectorySort()
+	}
+	panel.sortOptions.data.selected = setting.SortType
+	panel.sortOptions.data.reversed = setting.Reversed
+	panel.sortOptions.cursor = setting.SortType
+}
+
+// Save the sort settings of the panel for its location. Settings matching
+// the defaults are not kept, so that changing the defaults applies to them
+func (m *model) saveDirectorySort(panel *filePanel) {
+	setting := dirsort.Setting{
+		SortType: panel.sortOptions.data.selected,
+		Reversed: panel.sortOptions.data.reversed,
+	}
+	var err error
+	if setting == defaultDirectorySort() {
+		err = m.dirSorts.Delete(panel.location)
+	} else {
+		err = m.dirSorts.Set(panel.location, setting)
+	}
+	if err != nil {
+		slog.Error("Error while saving directory sort settings", "location", panel.location, "error", err)
+	}
+}
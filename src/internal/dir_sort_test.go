@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsort"
+)
+
+func TestModel_DirectorySort(t *testing.T) {
+	dir := t.TempDir()
+	downloads := filepath.Join(dir, "Downloads")
+	documents := filepath.Join(dir, "Documents")
+	require.NoError(t, os.Mkdir(downloads, 0755))
+	require.NoError(t, os.Mkdir(documents, 0755))
+	file := filepath.Join(t.TempDir(), "directory_sort.json")
+	m := defaultModelConfig(false, true, []string{downloads})
+	m.dirSorts = dirsort.New(file)
+	panel := &m.fileModel.filePanels[0]
+	require.Equal(t, common.Config.DefaultSortType, panel.sortOptions.data.selected)
+
+	m.openSortOptionsMenu()
+	panel.sortOptions.cursor = common.SortByDateModified
+	m.confirmSortOptions()
+	m.toggleReverseSort()
+
+	// Other directories keep the defaults
+	panel.changeLocation(documents)
+	m.handleLocationChange(panel)
+	assert.Equal(t, common.Config.DefaultSortType, panel.sortOptions.data.selected)
+	assert.Equal(t, common.Config.SortOrderReversed, panel.sortOptions.data.reversed)
+
+	panel.changeLocation(downloads)
+	m.handleLocationChange(panel)
+	assert.Equal(t, common.SortByDateModified, panel.sortOptions.data.selected)
+	assert.Equal(t, common.SortByDateModified, panel.sortOptions.cursor)
+	assert.Equal(t, !common.Config.SortOrderReversed, panel.sortOptions.data.reversed)
+
+	// Settings are saved to file, and applied to new panels
+	m = defaultModelConfig(false, true, []string{downloads})
+	m.dirSorts = dirsort.Load(file)
+	m.applyDirectorySort(&m.fileModel.filePanels[0])
+	assert.Equal(t, common.SortByDateModified, m.fileModel.filePanels[0].sortOptions.data.selected)
+
+	// Going back to the defaults forgets the directory
+	panel = &m.fileModel.filePanels[0]
+	panel.sortOptions.cursor = common.Config.DefaultSortType
+	m.confirmSortOptions()
+	m.toggleReverseSort()
+	_, ok := dirsort.Load(file).Get(downloads)
+	assert.False(t, ok)
+}
@@ -0,0 +1,253 @@
+package internal
+
+import (
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/dirwatch"
+	"github.com/yorukot/superfile/src/internal/ignore"
+)
+
+const (
+	// Changes are applied once no event came in for this long, so that bursts
+	// like extracting an archive don't update the panels on every event
+	dirWatchDebounce = 100 * time.Millisecond
+	// Flat listings with more directories are polled instead of watched, so
+	// that large trees don't use up the watches the system allows
+	maxWatchedFlattenDirs = 256
+)
+
+func notifyDirChanges() {
+	channel <- channelMessage{messageType: sendDirChanges}
+}
+
+// Watcher of the panel locations, or nil when the system doesn't allow
+// watching, in which case all the panels are polled
+func newDirWatcher() *dirwatch.Watcher {
+	watcher, err := dirwatch.New(dirWatchDebounce, notifyDirChanges)
+	if err != nil {
+		slog.Error("Error while creating directory watcher, directories are polled", "error", err)
+		return nil
+	}
+	return watcher
+}
+
+// Whether the changes in the panel location can be applied to the listing
+// item by item. Other listings are read again on changes. Fuzzy matches are
+// ordered by score, and the trees and flat listings span several directories
+func (panel *filePanel) updatesInPlace() bool {
+	return !panel.flatten && !panel.showsTree() &&
+		(panel.searchBar.Value() == "" || panel.searchMode != fuzzyMatch)
+}
+
+func (panel *filePanel) listingState(displayDotFile bool) listingState {
+	return listingState{
+		location:         panel.location,
+		sortType:         panel.sortOptions.data.selected,
+		reversed:         panel.sortOptions.data.reversed,
+		directoriesFirst: panel.sortOptions.data.directoriesFirst,
+		displayDotFile:   displayDotFile,
+		flatten:          panel.flatten,
+		treeView:         panel.treeView,
+		search:           panel.searchBar.Value(),
+		searchMode:       panel.searchMode,
+		tagFilter:        panel.tagFilter,
+		hideIgnored:      panel.hideIgnored,
+	}
+}
+
+// Directories whose changes affect the items listed by the panel: the
+// directories containing the find results, or the ones read for the listing.
+// None when the flat listing spans too many directories to watch them
+func (panel *filePanel) watchedDirs() []string {
+	if panel.finder.active {
+		var dirs []string
+		for _, item := range panel.element {
+			if dir := filepath.Dir(item.location); !slices.Contains(dirs, dir) {
+				dirs = append(dirs, dir)
+			}
+		}
+		return dirs
+	}
+	// The listing wasn't read yet
+	if len(panel.listedDirs) == 0 {
+		return []string{panel.location}
+	}
+	if panel.flatten && len(panel.listedDirs) > maxWatchedFlattenDirs {
+		return nil
+	}
+	return panel.listedDirs
+}
+
+// Watch the directories listed by the panels, and stop watching the others
+func (m *model) watchPanelLocations() {
+	var dirs []string
+	for _, panel := range m.fileModel.filePanels {
+		dirs = append(dirs, panel.watchedDirs()...)
+	}
+	m.dirWatcher.Watch(dirs)
+}
+
+// Whether all the directories listed by the panel are watched. The panel is
+// polled otherwise, like when it lists a network file system or a large
+// flat listing
+func (m *model) watchesPanel(panel *filePanel) bool {
+	dirs := panel.watchedDirs()
+	if len(dirs) == 0 {
+		return false
+	}
+	for _, dir := range dirs {
+		if !m.dirWatcher.Watched(dir) {
+			return false
+		}
+	}
+	return true
+}
+
+// Read the listing of the panel if it changed since it was last read, when
+// the panel is watched. Otherwise it returns false, and the panel is polled
+func (m *model) updateWatchedPanel(panel *filePanel) bool {
+	if !m.watchesPanel(panel) {
+		panel.watchedListing = listingState{}
+		return false
+	}
+	state := panel.listingState(m.toggleDotFile)
+	// The read time is reset to force reading the listing again
+	if state == panel.watchedListing && !panel.lastTimeGetElement.IsZero() {
+		return true
+	}
+	m.readPanelElements(panel)
+	panel.watchedListing = state
+	return true
+}
+
+// Apply the changes in watched directories to the panels listing them. Only
+// the changed items are read again when possible, otherwise the whole
+// listing is
+func (m *model) handleDirChanges(changes map[string][]string) {
+	for dir, paths := range changes {
+		m.gitStatuses.Invalidate(dir)
+		m.uncheckChangedTags(dir, paths)
+	}
+	m.markItemsSizesStale()
+	for i := range m.fileModel.filePanels {
+		panel := &m.fileModel.filePanels[i]
+		if panel.finder.active {
+			m.updateFindResults(panel, changes)
+			continue
+		}
+		if !slices.ContainsFunc(panel.watchedDirs(), func(dir string) bool { return changes[dir] != nil }) {
+			continue
+		}
+		panel.markListingStale()
+		// Listings not read since the panel is watched are read by
+		// getFilePanelItems
+		if panel.watchedListing != panel.listingState(m.toggleDotFile) {
+			continue
+		}
+		cursorLocation := ""
+		if panel.cursor < len(panel.element) {
+			cursorLocation = panel.element[panel.cursor].location
+		}
+		paths := changes[panel.location]
+		// Changed ignore files can hide or show any item
+		if !panel.updatesInPlace() || slices.Contains(paths, panel.location) ||
+			(panel.hideIgnored && slices.ContainsFunc(paths, ignore.IsIgnoreFile)) {
+			m.readPanelElements(panel)
+		} else {
+			panel.element = m.filterListing(panel,
+				updatedElements(panel.element, paths, m.toggleDotFile, panel.sortOptions.data, m.dirSizes))
+			panel.lastTimeGetElement = time.Now()
+		}
+		m.keepCursorOn(panel, cursorLocation)
+	}
+}
+
+// Drop the find results of the panel that were removed. Results are not
+// searched again, the find is a snapshot of the moment it ran
+func (m *model) updateFindResults(panel *filePanel, changes map[string][]string) {
+	changed := make(map[string]bool)
+	for _, paths := range changes {
+		for _, path := range paths {
+			changed[path] = true
+		}
+	}
+	cursorLocation := ""
+	if panel.cursor < len(panel.element) {
+		cursorLocation = panel.element[panel.cursor].location
+	}
+	panel.element = slices.DeleteFunc(panel.element, func(e element) bool {
+		// A directory is part of its changes when all of its content may
+		// have changed
+		if !changed[e.location] && !changed[filepath.Dir(e.location)] {
+			return false
+		}
+		_, err := os.Lstat(e.location)
+		return err != nil
+	})
+	m.keepCursorOn(panel, cursorLocation)
+}
+
+// Put the cursor back on the item at location if it is still listed, and
+// keep it within the listing otherwise
+func (m *model) keepCursorOn(panel *filePanel, location string) {
+	if index := slices.IndexFunc(panel.element, func(e element) bool {
+		return e.location == location
+	}); index >= 0 {
+		panel.cursor = index
+	}
+	panel.cursor = min(panel.cursor, max(0, len(panel.element)-1))
+	panel.render = min(panel.render, panel.cursor)
+	panel.render = max(panel.render, panel.cursor-panelElementHeight(m.mainPanelHeight)+1)
+}
+
+// Items of elements listed by the panel: the ones that are not hidden as
+// ignored, carrying the tag of the tag filter and matching the search bar
+func (m *model) filterListing(panel *filePanel, elements []element) []element {
+	elements = filterIgnored(elements, panel.ignoreMatcher())
+	if panel.tagFilter != "" {
+		elements = filterTagged(elements, panel.tagFilter, m.fileTags)
+	}
+	if query := panel.searchBar.Value(); query != "" {
+		elements = filterElements(elements, query, panel.searchMode)
+	}
+	return elements
+}
+
+// Return elements with the items at paths read again, and sorted again
+func updatedElements(elements []element, paths []string, displayDotFile bool,
+	sortOptions sortOptionsModelData, sizes *dirsize.Cache) []element {
+	changed := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		changed[path] = true
+	}
+	entries := make([]listedEntry, 0, len(elements)+len(paths))
+	for _, item := range elements {
+		if !changed[item.location] && item.info != nil {
+			entries = append(entries, elementEntry(item.location, item.name, item.info))
+		}
+	}
+	for path := range changed {
+		// Removed items are left out
+		info, err := os.Lstat(path)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, elementEntry(path, info.Name(), info))
+	}
+	return sortedElements(entries, displayDotFile, sortOptions, sizes)
+}
+
+func elementEntry(location string, name string, info fs.FileInfo) listedEntry {
+	return listedEntry{
+		DirEntry: fs.FileInfoToDirEntry(info),
+		dir:      filepath.Dir(location),
+		name:     name,
+		info:     info,
+	}
+}
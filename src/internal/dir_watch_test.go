@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/dirwatch"
+)
+
+func TestModel_DirWatch(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "c.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	m := defaultModelConfig(false, true, []string{dir})
+	// Changes are taken by the test instead of being sent to the channel
+	watcher, err := dirwatch.New(10*time.Millisecond, nil)
+	require.NoError(t, err)
+	m.dirWatcher = watcher
+	t.Cleanup(watcher.Close)
+	// Wait for count changes in changedDir, and apply them
+	applyChanges := func(changedDir string, count int) {
+		t.Helper()
+		changes := make(map[string][]string)
+		require.Eventually(t, func() bool {
+			for changedDir, paths := range m.dirWatcher.TakeChanges() {
+				changes[changedDir] = append(changes[changedDir], paths...)
+			}
+			return len(changes[changedDir]) >= count
+		}, time.Second, 5*time.Millisecond)
+		m.handleDirChanges(changes)
+	}
+	names := func() []string {
+		var result []string
+		for _, item := range m.fileModel.filePanels[0].element {
+			result = append(result, item.name)
+		}
+		return result
+	}
+
+	panel := &m.fileModel.filePanels[0]
+	m.getFilePanelItems()
+	require.True(t, m.dirWatcher.Watched(dir))
+	require.Len(t, panel.element, 2)
+	panel.cursor = 1
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), nil, 0644))
+	require.NoError(t, os.Remove(filepath.Join(dir, "a.txt")))
+	applyChanges(dir, 2)
+	require.Len(t, panel.element, 2)
+	assert.Equal(t, "b.txt", panel.element[0].name)
+	assert.Equal(t, "c.txt", panel.element[panel.cursor].name, "the cursor stays on the same item")
+
+	// The directory isn't polled, but it is read again when the listing changes
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "d.txt"), nil, 0644))
+	m.getFilePanelItems()
+	assert.Len(t, panel.element, 2)
+	m.toggleReverseSort()
+	m.getFilePanelItems()
+	require.Len(t, panel.element, 3)
+	assert.Equal(t, "d.txt", panel.element[0].name)
+	m.toggleReverseSort()
+
+	t.Run("The search filter applies to changed items", func(t *testing.T) {
+		panel.searchMode = exactMatch
+		panel.searchBar.SetValue("b")
+		m.getFilePanelItems()
+		require.Equal(t, []string{"b.txt"}, names())
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "bb.txt"), nil, 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "e.txt"), nil, 0644))
+		applyChanges(dir, 2)
+		assert.Equal(t, []string{"b.txt", "bb.txt"}, names())
+		panel.searchBar.SetValue("")
+	})
+
+	t.Run("Ignored items stay hidden", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".ignore"), []byte("*.log\n"), 0644))
+		m.toggleHideIgnored()
+		m.getFilePanelItems()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "x.log"), nil, 0644))
+		applyChanges(dir, 1)
+		assert.NotContains(t, names(), "x.log")
+
+		// The whole listing is filtered again when ignore files change
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".ignore"), []byte("*.log\ne.txt\n"), 0644))
+		applyChanges(dir, 1)
+		assert.NotContains(t, names(), "e.txt")
+		m.toggleHideIgnored()
+		require.NoError(t, os.Remove(filepath.Join(dir, ".ignore")))
+		applyChanges(dir, 1)
+	})
+
+	t.Run("The directories listed in flatten mode are watched", func(t *testing.T) {
+		sub := filepath.Join(dir, "sub")
+		require.NoError(t, os.Mkdir(sub, 0755))
+		m.toggleFlatten()
+		m.handleChannelMessage(receiveMessage(t, sendFlatElements))
+		m.getFilePanelItems()
+		require.True(t, m.dirWatcher.Watched(sub))
+		assert.NotContains(t, names(), filepath.Join("sub", "f.txt"))
+		require.NoError(t, os.WriteFile(filepath.Join(sub, "f.txt"), nil, 0644))
+		applyChanges(sub, 1)
+		// The tree is walked again in the background
+		m.handleChannelMessage(receiveMessage(t, sendFlatElements))
+		assert.Contains(t, names(), filepath.Join("sub", "f.txt"))
+		assert.NotEqual(t, listingState{}, panel.watchedListing, "the panel isn't polled")
+
+		// Flat listings of large trees are polled
+		listedDirs := panel.listedDirs
+		for i := len(panel.listedDirs); i <= maxWatchedFlattenDirs; i++ {
+			panel.listedDirs = append(panel.listedDirs, filepath.Join(dir, strconv.Itoa(i)))
+		}
+		assert.Empty(t, panel.watchedDirs())
+		assert.False(t, m.watchesPanel(panel))
+		panel.listedDirs = listedDirs
+		m.toggleFlatten()
+	})
+
+	t.Run("Removed find results are dropped", func(t *testing.T) {
+		result := filepath.Join(dir, "sub", "f.txt")
+		panel.finder.active = true
+		panel.element = []element{{name: filepath.Join("sub", "f.txt"), location: result}}
+		m.watchPanelLocations()
+		require.NoError(t, os.Remove(result))
+		applyChanges(filepath.Dir(result), 1)
+		assert.Empty(t, panel.element)
+	})
+}
@@ -0,0 +1,145 @@
+package dirsize
+
+import (
+	"cmp"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Most sizes kept by a cache. Past it, the least recently used quarter of
+// the sizes is dropped
+const maxSizes = 10000
+
+// Recursive sizes of directories, computed in the background. A size stays
+// valid as long as the modification time of its directory doesn't change.
+// That time only changes when entries are added, removed or renamed right
+// inside the directory, so changes deeper below it, like a file growing, are
+// missed until then.
+// All methods are safe to call on a nil *Cache, which computes nothing
+type Cache struct {
+	mu       sync.Mutex
+	sizes    map[string]cachedSize
+	maxSizes int
+	// Incremented on each use of a size, to know the least recently used
+	uses    uint64
+	pending map[string]bool
+	// Directories whose size was computed since the last TakeComputed call
+	computed []string
+	// Limits how many directories are walked at the same time
+	workers chan struct{}
+	// Called when sizes come in after the last TakeComputed call
+	notify func()
+}
+
+type cachedSize struct {
+	modTime time.Time
+	size    int64
+	lastUse uint64
+}
+
+// New returns a cache walking at most workers directories at the same time.
+// notify is called from a background goroutine when computed sizes are
+// available, and not again until they are taken with TakeComputed
+func New(workers int, notify func()) *Cache {
+	return &Cache{
+		sizes:    make(map[string]cachedSize),
+		maxSizes: maxSizes,
+		pending:  make(map[string]bool),
+		workers:  make(chan struct{}, workers),
+		notify:   notify,
+	}
+}
+
+// Size returns the size of the directory at path, if it was computed for
+// modTime. Otherwise it starts computing it in the background and returns
+// false
+func (c *Cache) Size(path string, modTime time.Time) (int64, bool) {
+	if c == nil {
+		return 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.sizes[path]; ok && cached.modTime.Equal(modTime) {
+		c.uses++
+		cached.lastUse = c.uses
+		c.sizes[path] = cached
+		return cached.size, true
+	}
+	if !c.pending[path] {
+		c.pending[path] = true
+		go c.compute(path, modTime)
+	}
+	return 0, false
+}
+
+func (c *Cache) compute(path string, modTime time.Time) {
+	c.workers <- struct{}{}
+	size := Compute(path)
+	<-c.workers
+
+	c.mu.Lock()
+	delete(c.pending, path)
+	c.uses++
+	c.sizes[path] = cachedSize{modTime: modTime, size: size, lastUse: c.uses}
+	if len(c.sizes) > c.maxSizes {
+		c.dropLeastRecentlyUsed()
+	}
+	c.computed = append(c.computed, path)
+	first := len(c.computed) == 1
+	c.mu.Unlock()
+
+	if first && c.notify != nil {
+		c.notify()
+	}
+}
+
+// Drop the least recently used quarter of the sizes. c.mu must be held
+func (c *Cache) dropLeastRecentlyUsed() {
+	paths := slices.SortedFunc(maps.Keys(c.sizes), func(a, b string) int {
+		return cmp.Compare(c.sizes[a].lastUse, c.sizes[b].lastUse)
+	})
+	for _, path := range paths[:len(paths)/4+1] {
+		delete(c.sizes, path)
+	}
+}
+
+// TakeComputed returns the directories whose size was computed since the
+// last call
+func (c *Cache) TakeComputed() []string {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	computed := c.computed
+	c.computed = nil
+	return computed
+}
+
+// Compute returns the total size of the files below the directory at path.
+// Symlinks are not followed, and unreadable items are skipped
+func Compute(path string) int64 {
+	var size int64
+	walkErr := filepath.WalkDir(path, func(itemPath string, entry fs.DirEntry, err error) error {
+		// The content of unreadable directories is skipped
+		if err != nil {
+			slog.Debug("Skipping unreadable item in dir size", "path", itemPath, "error", err)
+			return nil
+		}
+		if !entry.IsDir() {
+			if info, infoErr := entry.Info(); infoErr == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	if walkErr != nil {
+		slog.Error("errors during WalkDir", "error", walkErr)
+	}
+	return size
+}
@@ -0,0 +1,88 @@
+package dirsize
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "top.txt"), make([]byte, 100), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b", "deep.txt"), make([]byte, 20), 0644))
+	return dir
+}
+
+func TestCompute(t *testing.T) {
+	dir := setupDir(t)
+	assert.Equal(t, int64(120), Compute(dir))
+	assert.Equal(t, int64(20), Compute(filepath.Join(dir, "a")))
+	assert.Zero(t, Compute(filepath.Join(dir, "missing")))
+}
+
+func TestCache(t *testing.T) {
+	dir := setupDir(t)
+	var notified atomic.Int32
+	c := New(2, func() { notified.Add(1) })
+	modTime := time.Now()
+
+	_, ok := c.Size(dir, modTime)
+	assert.False(t, ok, "sizes are computed in the background")
+	require.Eventually(t, func() bool { return notified.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	size, ok := c.Size(dir, modTime)
+	require.True(t, ok)
+	assert.Equal(t, int64(120), size)
+
+	// Notified again only once the computed sizes are taken
+	_, ok = c.Size(filepath.Join(dir, "a"), modTime)
+	assert.False(t, ok)
+	require.Eventually(t, func() bool {
+		_, ok := c.Size(filepath.Join(dir, "a"), modTime)
+		return ok
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(1), notified.Load())
+	assert.ElementsMatch(t, []string{dir, filepath.Join(dir, "a")}, c.TakeComputed())
+	assert.Empty(t, c.TakeComputed())
+
+	// A different modification time means the directory changed
+	_, ok = c.Size(dir, modTime.Add(time.Second))
+	assert.False(t, ok)
+	require.Eventually(t, func() bool { return notified.Load() == 2 }, time.Second, 5*time.Millisecond)
+
+	var nilCache *Cache
+	_, ok = nilCache.Size(dir, modTime)
+	assert.False(t, ok)
+	assert.Nil(t, nilCache.TakeComputed())
+}
+
+func TestCacheEviction(t *testing.T) {
+	dir := setupDir(t)
+	c := New(1, nil)
+	c.maxSizes = 2
+	modTime := time.Now()
+	sizeOf := func(path string) {
+		t.Helper()
+		c.Size(path, modTime)
+		require.Eventually(t, func() bool {
+			_, ok := c.Size(path, modTime)
+			return ok
+		}, time.Second, 5*time.Millisecond)
+	}
+
+	sizeOf(dir)
+	sizeOf(filepath.Join(dir, "a"))
+	// Used last, the size of dir is kept once a third size comes in
+	c.Size(dir, modTime)
+	sizeOf(filepath.Join(dir, "a", "b"))
+	assert.Len(t, c.sizes, 2)
+	assert.Contains(t, c.sizes, dir)
+	assert.NotContains(t, c.sizes, filepath.Join(dir, "a"))
+}
@@ -0,0 +1,67 @@
+package dirsort
+
+import "github.com/yorukot/superfile/src/internal/utils"
+
+// Sort type and order chosen for a directory
+type Setting struct {
+	SortType int  `json:"sort_type"`
+	Reversed bool `json:"reversed"`
+}
+
+// Sort settings of directories, saved to file on every change. A nil *Store
+// remembers nothing
+type Store struct {
+	file     string
+	settings map[string]Setting
+}
+
+func New(file string) *Store {
+	return &Store{
+		file:     file,
+		settings: make(map[string]Setting),
+	}
+}
+
+// Load the settings saved in file, none when it can't be read
+func Load(file string) *Store {
+	s := New(file)
+	var settings map[string]Setting
+	if utils.ReadJSONFile(file, &settings) && settings != nil {
+		s.settings = settings
+	}
+	return s
+}
+
+func (s *Store) save() error {
+	return utils.WriteJSONFile(s.file, s.settings)
+}
+
+func (s *Store) Get(dir string) (Setting, bool) {
+	if s == nil {
+		return Setting{}, false
+	}
+	setting, ok := s.settings[dir]
+	return setting, ok
+}
+
+func (s *Store) Set(dir string, setting Setting) error {
+	if s == nil {
+		return nil
+	}
+	if current, ok := s.settings[dir]; ok && current == setting {
+		return nil
+	}
+	s.settings[dir] = setting
+	return s.save()
+}
+
+func (s *Store) Delete(dir string) error {
+	if s == nil {
+		return nil
+	}
+	if _, ok := s.settings[dir]; !ok {
+		return nil
+	}
+	delete(s.settings, dir)
+	return s.save()
+}
@@ -0,0 +1,47 @@
+package dirsort
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "directory_sort.json")
+	s := Load(file)
+	_, ok := s.Get("/tmp")
+	assert.False(t, ok)
+
+	require.NoError(t, s.Set("/tmp", Setting{SortType: 2, Reversed: true}))
+	require.NoError(t, s.Set("/home/user/Downloads", Setting{SortType: 1}))
+	require.NoError(t, s.Delete("/home/user/Downloads"))
+	require.NoError(t, s.Delete("/not/saved"))
+
+	// Changes are saved immediately
+	loaded := Load(file)
+	setting, ok := loaded.Get("/tmp")
+	require.True(t, ok)
+	assert.Equal(t, Setting{SortType: 2, Reversed: true}, setting)
+	_, ok = loaded.Get("/home/user/Downloads")
+	assert.False(t, ok)
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "directory_sort.json")
+	require.NoError(t, os.WriteFile(file, []byte("not json"), 0644))
+	s := Load(file)
+	_, ok := s.Get("/tmp")
+	assert.False(t, ok)
+	require.NoError(t, s.Set("/tmp", Setting{SortType: 1}))
+}
+
+func TestNilStore(t *testing.T) {
+	var s *Store
+	_, ok := s.Get("/tmp")
+	assert.False(t, ok)
+	require.NoError(t, s.Set("/tmp", Setting{SortType: 1}))
+	require.NoError(t, s.Delete("/tmp"))
+}
@@ -0,0 +1,226 @@
+package dirwatch
+
+import (
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Changes are reported at least this often during long bursts of events
+const maxDelay = time.Second
+
+// Watches the content of directories. Events are debounced: changes are
+// reported once no event came in for the debounce delay. All methods are safe
+// to call on a nil *Watcher, which watches nothing
+type Watcher struct {
+	mu      sync.Mutex
+	watcher *fsnotify.Watcher
+	// Directories to watch, false for the ones that can't be watched
+	watched map[string]bool
+	// Changed paths by watched directory since the last TakeChanges call. The
+	// directory itself is listed when all of its content may have changed
+	changes map[string]map[string]bool
+	// Fires debounce after the last event, or maxDelay after the first one
+	timer      *time.Timer
+	burstStart time.Time
+	debounce   time.Duration
+	// Whether notify was called since the last TakeChanges call
+	notified bool
+	notify   func()
+}
+
+// New returns a watcher calling notify from a background goroutine when
+// changes are available, and not again until they are taken with
+// TakeChanges. It fails when the system doesn't allow watching
+func New(debounce time.Duration, notify func()) (*Watcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		watcher:  fsWatcher,
+		watched:  make(map[string]bool),
+		changes:  make(map[string]map[string]bool),
+		debounce: debounce,
+		notify:   notify,
+	}
+	go w.run()
+	return w, nil
+}
+
+// Watch the given directories, and stop watching the others. Directories on
+// file systems whose changes can't all be seen, like network file systems,
+// are not watched
+func (w *Watcher) Watch(dirs []string) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	wanted := make(map[string]bool, len(dirs))
+	for _, dir := range dirs {
+		wanted[dir] = true
+	}
+	for dir, watched := range w.watched {
+		if wanted[dir] {
+			continue
+		}
+		if watched {
+			if err := w.watcher.Remove(dir); err != nil {
+				slog.Debug("Error while removing directory watch", "dir", dir, "error", err)
+			}
+		}
+		delete(w.watched, dir)
+		delete(w.changes, dir)
+	}
+	for dir := range wanted {
+		if _, ok := w.watched[dir]; ok {
+			continue
+		}
+		w.watched[dir] = false
+		if !supportsWatching(dir) {
+			slog.Debug("Directory can't be watched, polling it", "dir", dir)
+			continue
+		}
+		if err := w.watcher.Add(dir); err != nil {
+			slog.Debug("Error while watching directory, polling it", "dir", dir, "error", err)
+			continue
+		}
+		w.watched[dir] = true
+	}
+}
+
+// Watched returns whether changes to the content of dir are reported
+func (w *Watcher) Watched(dir string) bool {
+	if w == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.watched[dir]
+}
+
+// TakeChanges returns the paths changed since the last call, by watched
+// directory. The directory itself is listed when all of its content may have
+// changed, like after it was removed
+func (w *Watcher) TakeChanges() map[string][]string {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	changes := make(map[string][]string, len(w.changes))
+	for dir, paths := range w.changes {
+		for path := range paths {
+			changes[dir] = append(changes[dir], path)
+		}
+	}
+	w.changes = make(map[string]map[string]bool)
+	w.notified = false
+	return changes
+}
+
+func (w *Watcher) Close() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	if err := w.watcher.Close(); err != nil {
+		slog.Error("Error while closing directory watcher", "error", err)
+	}
+}
+
+func (w *Watcher) run() {
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			w.record(event)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Error("Error while watching directories", "error", err)
+			// Events were lost, so all the watched directories are reloaded
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				w.recordAll()
+			}
+		}
+	}
+}
+
+func (w *Watcher) record(event fsnotify.Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	dir := filepath.Dir(event.Name)
+	switch {
+	case w.watched[event.Name]:
+		if !event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
+			return
+		}
+		// The watch is gone with the directory, it is added again by the next
+		// Watch call if the directory comes back
+		delete(w.watched, event.Name)
+		w.addChange(event.Name, event.Name)
+	case w.watched[dir]:
+		w.addChange(dir, event.Name)
+	default:
+		return
+	}
+	w.schedule()
+}
+
+func (w *Watcher) recordAll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for dir, watched := range w.watched {
+		if watched {
+			w.addChange(dir, dir)
+		}
+	}
+	w.schedule()
+}
+
+func (w *Watcher) addChange(dir string, path string) {
+	if w.changes[dir] == nil {
+		w.changes[dir] = make(map[string]bool)
+	}
+	w.changes[dir][path] = true
+}
+
+// Push back the report of the changes, unless the burst of events lasts for
+// too long already
+func (w *Watcher) schedule() {
+	if w.timer == nil {
+		w.burstStart = time.Now()
+		w.timer = time.AfterFunc(w.debounce, w.flush)
+		return
+	}
+	if time.Since(w.burstStart)+w.debounce < maxDelay {
+		w.timer.Reset(w.debounce)
+	}
+}
+
+func (w *Watcher) flush() {
+	w.mu.Lock()
+	w.timer = nil
+	notify := len(w.changes) > 0 && !w.notified
+	if notify {
+		w.notified = true
+	}
+	w.mu.Unlock()
+
+	if notify && w.notify != nil {
+		w.notify()
+	}
+}
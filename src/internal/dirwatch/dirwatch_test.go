@@ -0,0 +1,59 @@
+package dirwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "sub")
+	require.NoError(t, os.Mkdir(sub, 0755))
+	var notified atomic.Int32
+	w, err := New(20*time.Millisecond, func() { notified.Add(1) })
+	require.NoError(t, err)
+	defer w.Close()
+
+	w.Watch([]string{dir, sub})
+	require.True(t, w.Watched(dir))
+	require.True(t, w.Watched(sub))
+	assert.False(t, w.Watched(filepath.Join(dir, "other")))
+
+	// A burst of events is reported once
+	for _, name := range []string{"a.txt", "b.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("content"), 0644))
+	}
+	require.Eventually(t, func() bool { return notified.Load() == 1 }, time.Second, 5*time.Millisecond)
+	changes := w.TakeChanges()
+	assert.ElementsMatch(t, []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")}, changes[dir])
+	assert.Empty(t, w.TakeChanges())
+
+	// Removed directories are reported as fully changed, and not watched anymore
+	require.NoError(t, os.Remove(sub))
+	require.Eventually(t, func() bool { return notified.Load() == 2 }, time.Second, 5*time.Millisecond)
+	changes = w.TakeChanges()
+	assert.Contains(t, changes[sub], sub)
+	assert.False(t, w.Watched(sub))
+
+	// Directories left out of Watch aren't reported
+	w.Watch(nil)
+	assert.False(t, w.Watched(dir))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.txt"), nil, 0644))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(2), notified.Load())
+	assert.Empty(t, w.TakeChanges())
+}
+
+func TestNilWatcher(t *testing.T) {
+	var w *Watcher
+	w.Watch([]string{t.TempDir()})
+	assert.False(t, w.Watched(t.TempDir()))
+	assert.Empty(t, w.TakeChanges())
+	w.Close()
+}
@@ -0,0 +1,20 @@
+//go:build darwin || freebsd
+
+package dirwatch
+
+import (
+	"slices"
+
+	"golang.org/x/sys/unix"
+)
+
+// Whether changes to the content of dir are all seen by kqueue. Changes made
+// by other machines on network file systems aren't
+func supportsWatching(dir string) bool {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return false
+	}
+	fsType := unix.ByteSliceToString(stat.Fstypename[:])
+	return !slices.Contains([]string{"nfs", "smbfs", "afpfs", "webdav", "macfuse", "osxfuse", "fusefs"}, fsType)
+}
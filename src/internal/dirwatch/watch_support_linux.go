@@ -0,0 +1,21 @@
+package dirwatch
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// Whether changes to the content of dir are all seen by inotify. Changes made
+// by other machines on network file systems aren't
+func supportsWatching(dir string) bool {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return false
+	}
+	switch uint32(stat.Type) { //nolint: gosec // File system magic numbers are 32 bits
+	case unix.NFS_SUPER_MAGIC, unix.SMB_SUPER_MAGIC, unix.SMB2_SUPER_MAGIC, unix.CIFS_SUPER_MAGIC,
+		unix.FUSE_SUPER_MAGIC, unix.V9FS_MAGIC, unix.AFS_SUPER_MAGIC, unix.CODA_SUPER_MAGIC,
+		unix.CEPH_SUPER_MAGIC:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !freebsd
+
+package dirwatch
+
+// File systems can't be told apart here, all of them are watched
+func supportsWatching(_ string) bool {
+	return true
+}
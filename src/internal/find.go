@@ -0,0 +1,309 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+const (
+	// Larger files are skipped by the content search
+	maxContentSearchFileSize = 50 * 1024 * 1024
+	// The rest of a file is skipped by the content search after a longer line
+	maxContentSearchLineLength = 1024 * 1024
+	// Length of the matching line shown in content search results
+	maxContentMatchLength = 200
+)
+
+// Returns the results among the entries of directory dir
+type dirSearcher func(dir string, entries []os.DirEntry) []element
+
+func relativePath(root string, location string) string {
+	relPath, err := filepath.Rel(root, location)
+	if err != nil {
+		return location
+	}
+	return relPath
+}
+
+func nameSearcher(root string, match nameMatcher) dirSearcher {
+	return func(dir string, entries []os.DirEntry) []element {
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name()
+		}
+		var results []element
+		for _, i := range match(names) {
+			location := filepath.Join(dir, names[i])
+			results = append(results, element{
+				name:      relativePath(root, location),
+				location:  location,
+				directory: entries[i].IsDir(),
+			})
+		}
+		return results
+	}
+}
+
+func contentSearcher(ctx context.Context, root string, match lineMatcher) dirSearcher {
+	return func(dir string, entries []os.DirEntry) []element {
+		var results []element
+		for _, entry := range entries {
+			if ctx.Err() != nil {
+				return results
+			}
+			if !entry.Type().IsRegular() {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil || info.Size() > maxContentSearchFileSize {
+				continue
+			}
+			location := filepath.Join(dir, entry.Name())
+			results = append(results, searchFileContent(location, relativePath(root, location), match)...)
+		}
+		return results
+	}
+}
+
+// Return a result for each line of a text file matching, named
+// relPath:line: match. Binary files are skipped
+func searchFileContent(location string, relPath string, match lineMatcher) []element {
+	isText, err := common.IsTextFile(location)
+	if err != nil || !isText {
+		return nil
+	}
+	file, err := os.Open(location)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var results []element
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxContentSearchLineLength)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if !match(line) {
+			continue
+		}
+		matchText := common.TruncateText(common.MakePrintable(strings.TrimSpace(line)), maxContentMatchLength, "...")
+		results = append(results, element{
+			name:     relPath + ":" + strconv.Itoa(lineNumber) + ": " + matchText,
+			location: location,
+			line:     lineNumber,
+		})
+	}
+	if err = scanner.Err(); err != nil {
+		slog.Debug("Content search stopped early in file", "file", location, "error", err)
+	}
+	return results
+}
+
+// Walk the subtree of root breadth first and send the results of search to
+// the channel, in one batch per directory. Symlinks to directories are not
+// followed. Stops early when ctx is cancelled.
+func findItems(ctx context.Context, id int, root string, search dirSearcher, showHidden bool) {
+	dirs := []string{root}
+	for len(dirs) > 0 {
+		if ctx.Err() != nil {
+			return
+		}
+		dir := dirs[0]
+		dirs = dirs[1:]
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			slog.Debug("Skipping unreadable directory in find", "dir", dir, "error", err)
+			continue
+		}
+
+		if !showHidden {
+			entries = slices.DeleteFunc(entries, func(entry os.DirEntry) bool {
+				return strings.HasPrefix(entry.Name(), ".")
+			})
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				dirs = append(dirs, filepath.Join(dir, entry.Name()))
+			}
+		}
+
+		results := search(dir, entries)
+		if len(results) > 0 && !sendFindMessage(ctx, findResults{id: id, elements: results}) {
+			return
+		}
+	}
+	sendFindMessage(ctx, findResults{id: id, done: true})
+}
+
+// Returns false if ctx was cancelled before the message could be sent
+func sendFindMessage(ctx context.Context, results findResults) bool {
+	message := channelMessage{
+		messageType: sendFindResults,
+		findResults: results,
+	}
+	select {
+	case channel <- message:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Stop the running find, if any, and go back to listing the panel location
+func (panel *filePanel) closeFinder() {
+	if !panel.finder.active {
+		return
+	}
+	if panel.finder.cancel != nil {
+		panel.finder.cancel()
+	}
+	panel.finder = finder{mode: panel.finder.mode}
+	panel.resetElements()
+	panel.cursor = 0
+	panel.render = 0
+}
+
+// Message shown in place of the items of an empty panel
+func (panel *filePanel) emptyMessage() string {
+	switch {
+	case !panel.finder.active:
+		if panel.flatten && panel.flatWalk.running {
+			return icon.InOperation + "  Listing..."
+		}
+		if _, err := newNameMatcher(panel.searchMode, panel.searchBar.Value()); err != nil {
+			return icon.Error + "  " + err.Error()
+		}
+		if panel.tagFilter != "" {
+			return icon.Error + "  No item tagged " + panel.tagFilter
+		}
+		return icon.Error + "  No such file or directory"
+	case panel.finder.err != "":
+		return icon.Error + "  " + panel.finder.err
+	case panel.finder.running:
+		return icon.InOperation + "  Searching..."
+	case panel.finder.textInput.Focused():
+		return icon.Search + "  Press " + common.Hotkeys.ConfirmTyping[0] + " to search"
+	}
+	return icon.Error + "  No matching file"
+}
+
+// Open the find bar of the focused panel, searching file names or file
+// content. The results of a previous query stay listed until a new one is
+// confirmed
+func (m *model) openFindBar(content bool) {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	if panel.finder.active && panel.finder.content != content {
+		panel.closeFinder()
+	}
+	if !panel.finder.active {
+		mode := panel.finder.mode
+		if content && mode != regexMatch {
+			mode = exactMatch
+		} else if !content && mode == exactMatch {
+			mode = fuzzyMatch
+		}
+		panel.finder = finder{
+			active:    true,
+			content:   content,
+			textInput: common.GenerateFindBar(),
+			mode:      mode,
+		}
+		if content {
+			panel.finder.textInput.Placeholder = "Search file contents"
+		}
+		panel.element = nil
+		panel.cursor = 0
+		panel.render = 0
+	}
+	panel.finder.textInput.Prompt = searchPrompt(panel.finder.mode, false)
+	panel.finder.textInput.Focus()
+	m.firstTextInput = true
+}
+
+func (m *model) findBarKey(msg string) {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	switch {
+	case slices.Contains(common.Hotkeys.CancelTyping, msg):
+		panel.closeFinder()
+	case slices.Contains(common.Hotkeys.ConfirmTyping, msg):
+		panel.finder.textInput.Blur()
+		m.startFind()
+	case slices.Contains(common.Hotkeys.NextSearchMode, msg):
+		panel.finder.mode = panel.finder.mode.next(panel.finder.content)
+		panel.finder.textInput.Prompt = searchPrompt(panel.finder.mode, false)
+	}
+}
+
+// Start a find for the query of the find bar, cancelling the previous one
+func (m *model) startFind() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	query := panel.finder.textInput.Value()
+	if query == "" {
+		panel.closeFinder()
+		return
+	}
+	if panel.finder.cancel != nil {
+		panel.finder.cancel()
+	}
+	panel.element = nil
+	panel.cursor = 0
+	panel.render = 0
+	panel.finder.err = ""
+	panel.finder.running = false
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var search dirSearcher
+	if panel.finder.content {
+		match, err := newLineMatcher(panel.finder.mode, query)
+		if err != nil {
+			panel.finder.err = err.Error()
+			cancel()
+			return
+		}
+		search = contentSearcher(ctx, panel.location, match)
+	} else {
+		match, err := newNameMatcher(panel.finder.mode, query)
+		if err != nil {
+			panel.finder.err = err.Error()
+			cancel()
+			return
+		}
+		search = nameSearcher(panel.location, match)
+	}
+	m.lastFindID++
+	panel.finder.id = m.lastFindID
+	panel.finder.cancel = cancel
+	panel.finder.running = true
+	go findItems(ctx, panel.finder.id, panel.location, search, m.toggleDotFile)
+}
+
+// Whether the cursor of the focused file panel is on a content search result
+func (m *model) cursorOnContentMatch() bool {
+	panel := m.fileModel.filePanels[m.filePanelFocusIndex]
+	return m.focusPanel == nonePanelFocus && panel.focusType == focus && panel.panelMode == browserMode &&
+		panel.finder.active && len(panel.element) > 0 && panel.element[panel.cursor].line > 0
+}
+
+// Add results of a running find to the panel it was started in
+func (m *model) handleFindResults(results findResults) {
+	for i := range m.fileModel.filePanels {
+		panel := &m.fileModel.filePanels[i]
+		if !panel.finder.active || panel.finder.id != results.id {
+			continue
+		}
+		panel.element = append(panel.element, results.elements...)
+		if results.done {
+			panel.finder.running = false
+			panel.finder.cancel()
+		}
+	}
+}
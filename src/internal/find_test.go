@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+// Receive the next message from the channel, which must be of messageType.
+// Metadata loaded in the background by earlier tests can come in first, and
+// is skipped
+func receiveMessage(t *testing.T, messageType channelMessageType) channelMessage {
+	t.Helper()
+	for {
+		msg := <-channel
+		if msg.messageType != sendMetadata {
+			require.Equal(t, messageType, msg.messageType)
+			return msg
+		}
+	}
+}
+
+func Test_findItems(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".hidden"), 0755))
+	for _, file := range []string{"x.go", "a/y.go", "a/b/z.go", "a/b/z.txt", ".hidden/h.go"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, 0644))
+	}
+	match, err := newNameMatcher(globMatch, "*.go")
+	require.NoError(t, err)
+
+	collect := func(showHidden bool) []string {
+		go findItems(context.Background(), 1, dir, nameSearcher(dir, match), showHidden)
+		var found []string
+		for {
+			msg := receiveMessage(t, sendFindResults)
+			for _, e := range msg.findResults.elements {
+				found = append(found, e.name)
+			}
+			if msg.findResults.done {
+				return found
+			}
+		}
+	}
+
+	assert.Equal(t, []string{"x.go", filepath.Join("a", "y.go"), filepath.Join("a", "b", "z.go")}, collect(false),
+		"results should be relative to root and shallow ones should come first")
+	assert.Contains(t, collect(true), filepath.Join(".hidden", "h.go"))
+}
+
+func TestModel_Find(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a.txt"), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "target.txt"), nil, 0644))
+
+	m := defaultModelConfig(false, true, []string{dir})
+	panel := &m.fileModel.filePanels[0]
+	panel.focusType = focus
+	_, err := TeaUpdate(&m, tea.WindowSizeMsg{Width: 150, Height: 40})
+	require.NoError(t, err)
+
+	m.normalAndBrowserModeKey(common.Hotkeys.FindRecursively[0])
+	require.True(t, panel.finder.active)
+	require.True(t, panel.finder.textInput.Focused())
+	m.findBarKey(common.Hotkeys.NextSearchMode[0])
+	assert.Equal(t, globMatch, panel.finder.mode)
+
+	panel.finder.textInput.SetValue("target*")
+	m.findBarKey(common.Hotkeys.ConfirmTyping[0])
+	require.True(t, panel.finder.running)
+	for panel.finder.running {
+		m.handleChannelMessage(<-channel)
+	}
+	require.Len(t, panel.element, 1)
+	assert.Equal(t, filepath.Join("sub", "target.txt"), panel.element[0].name)
+
+	// Results are not replaced by the directory content
+	m.getFilePanelItems()
+	require.Len(t, panel.element, 1)
+
+	m.enterPanel()
+	assert.False(t, panel.finder.active)
+	assert.Equal(t, filepath.Join(dir, "sub"), panel.location)
+	assert.Equal(t, filepath.Join(dir, "sub", "target.txt"), panel.element[panel.cursor].location)
+
+	t.Run("Invalid query shows error", func(t *testing.T) {
+		m.openFindBar(false)
+		panel.finder.mode = regexMatch
+		panel.finder.textInput.SetValue("(")
+		m.findBarKey(common.Hotkeys.ConfirmTyping[0])
+		assert.False(t, panel.finder.running)
+		assert.Contains(t, panel.emptyMessage(), "invalid regular expression")
+
+		m.parentDirectory()
+		assert.False(t, panel.finder.active)
+		assert.Equal(t, filepath.Join(dir, "sub"), panel.location, "leaving find results should not change location")
+	})
+}
+
+func Test_searchFileContent(t *testing.T) {
+	dir := t.TempDir()
+	textFile := filepath.Join(dir, "a.txt")
+	binaryFile := filepath.Join(dir, "b.bin")
+	require.NoError(t, os.WriteFile(textFile, []byte("foo\n  needle one\nbar\nneedle two\n"), 0644))
+	require.NoError(t, os.WriteFile(binaryFile, []byte("needle\x00\x01\x02"), 0644))
+
+	match, err := newLineMatcher(exactMatch, "needle")
+	require.NoError(t, err)
+	results := searchFileContent(textFile, "a.txt", match)
+	require.Len(t, results, 2)
+	assert.Equal(t, "a.txt:2: needle one", results[0].name)
+	assert.Equal(t, 2, results[0].line)
+	assert.Equal(t, 4, results[1].line)
+	assert.Empty(t, searchFileContent(binaryFile, "b.bin", match), "binary files should be skipped")
+
+	match, err = newLineMatcher(regexMatch, "^bar$")
+	require.NoError(t, err)
+	results = searchFileContent(textFile, "a.txt", match)
+	require.Len(t, results, 1)
+	assert.Equal(t, 3, results[0].line)
+
+	_, err = newLineMatcher(regexMatch, "(")
+	assert.Error(t, err)
+}
+
+func TestModel_ContentSearch(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a.go"), []byte("package a\n\nfunc Target() {}\n"), 0644))
+
+	m := defaultModelConfig(false, true, []string{dir})
+	panel := &m.fileModel.filePanels[0]
+	panel.focusType = focus
+	_, err := TeaUpdate(&m, tea.WindowSizeMsg{Width: 150, Height: 40})
+	require.NoError(t, err)
+
+	m.normalAndBrowserModeKey(common.Hotkeys.SearchContent[0])
+	require.True(t, panel.finder.content)
+	assert.Equal(t, exactMatch, panel.finder.mode)
+	panel.finder.textInput.SetValue("Target")
+	m.findBarKey(common.Hotkeys.ConfirmTyping[0])
+	for panel.finder.running {
+		m.handleChannelMessage(<-channel)
+	}
+	require.Len(t, panel.element, 1)
+	assert.Equal(t, filepath.Join("sub", "a.go")+":3: func Target() {}", panel.element[0].name)
+	assert.True(t, m.cursorOnContentMatch())
+
+	content, err := readFileContent(panel.element[0].location, 100, 3, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "func Target() {}\n", content)
+
+	// Switching to a name search starts over
+	m.openFindBar(false)
+	assert.False(t, panel.finder.content)
+	assert.Equal(t, fuzzyMatch, panel.finder.mode)
+	assert.Empty(t, panel.element)
+}
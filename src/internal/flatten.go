@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/ignore"
+)
+
+const (
+	// Flattening a huge tree, like the root directory, would take too much
+	// memory and time
+	maxFlattenItems = 50000
+	// Polled panels in flatten mode walk their tree again at most this often,
+	// as each walk ends with an update of the model
+	flatWalkPollInterval = 3 * time.Second
+)
+
+// Return all the items below location, named by their path relative to it,
+// and whether the walk stopped at maxFlattenItems. Items more than maxDepth
+// levels below location are skipped, unless maxDepth is 0, as well as the
+// ignored items and their content. Stops early when ctx is cancelled
+func returnFlatElements(ctx context.Context, location string, displayDotFile bool, sortOptions sortOptionsModelData,
+	maxDepth int, ignored *ignore.Matcher, sizes *dirsize.Cache) ([]element, bool) {
+	var entries []listedEntry
+	truncated := false
+	err := filepath.WalkDir(location, func(path string, entry fs.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return filepath.SkipAll
+		}
+		if err != nil {
+			slog.Debug("Skipping unreadable item in flatten mode", "path", path, "error", err)
+			return nil
+		}
+		if path == location {
+			return nil
+		}
+		if (!displayDotFile && strings.HasPrefix(entry.Name(), ".")) || ignored.Ignored(path, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if len(entries) >= maxFlattenItems {
+			truncated = true
+			return filepath.SkipAll
+		}
+
+		relPath := relativePath(location, path)
+		entries = append(entries, listedEntry{DirEntry: entry, dir: filepath.Dir(path), name: relPath})
+		depth := strings.Count(relPath, string(os.PathSeparator)) + 1
+		if entry.IsDir() && maxDepth > 0 && depth >= maxDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("Error while listing items in flatten mode", "error", err)
+	}
+	return sortedElements(entries, displayDotFile, sortOptions, sizes), truncated
+}
+
+// Return the items of the last flatten walk of the panel. A new walk is
+// started in the background when the listing changed or the walk is stale,
+// the items of the previous walk of the same location are listed until it
+// is done
+func (m *model) flatElements(panel *filePanel, ignored *ignore.Matcher) []element {
+	walk := &panel.flatWalk
+	state := panel.listingState(m.toggleDotFile)
+	state.search, state.searchMode, state.tagFilter = "", 0, ""
+	if walk.state == state && !walk.stale {
+		return walk.elements
+	}
+
+	if walk.cancel != nil {
+		walk.cancel()
+	}
+	elements, truncated := walk.elements, walk.truncated
+	if walk.state.location != state.location {
+		elements, truncated = nil, false
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.lastFlatWalkID++
+	*walk = flatWalk{
+		state:          state,
+		elements:       elements,
+		truncated:      truncated,
+		cursorLocation: walk.cursorLocation,
+		id:             m.lastFlatWalkID,
+		running:        true,
+		cancel:         cancel,
+	}
+	go walkFlatElements(ctx, walk.id, panel.location, m.toggleDotFile, panel.sortOptions.data,
+		common.Config.FlattenMaxDepth, ignored, m.dirSizes)
+	return elements
+}
+
+func walkFlatElements(ctx context.Context, id int, location string, displayDotFile bool,
+	sortOptions sortOptionsModelData, maxDepth int, ignored *ignore.Matcher, sizes *dirsize.Cache) {
+	elements, truncated := returnFlatElements(ctx, location, displayDotFile, sortOptions, maxDepth, ignored, sizes)
+	message := channelMessage{
+		messageType:  sendFlatElements,
+		flatElements: flatElements{id: id, elements: elements, truncated: truncated},
+	}
+	select {
+	case channel <- message:
+	case <-ctx.Done():
+	}
+}
+
+// Cancel the running flatten walk, if any, and drop its items
+func (panel *filePanel) stopFlatWalk() {
+	if panel.flatWalk.cancel != nil {
+		panel.flatWalk.cancel()
+	}
+	panel.flatWalk = flatWalk{}
+}
+
+// List the items of the latest flatten walk in the panel it was started in,
+// keeping the cursor on the same item
+func (m *model) handleFlatElements(results flatElements) {
+	for i := range m.fileModel.filePanels {
+		panel := &m.fileModel.filePanels[i]
+		walk := &panel.flatWalk
+		if !walk.running || walk.id != results.id {
+			continue
+		}
+		walk.cancel()
+		walk.elements, walk.truncated, walk.running = results.elements, results.truncated, false
+		walk.doneTime = time.Now()
+		cursorLocation := walk.cursorLocation
+		walk.cursorLocation = ""
+		if !panel.flatten || panel.finder.active {
+			continue
+		}
+		if cursorLocation == "" && panel.cursor < len(panel.element) {
+			cursorLocation = panel.element[panel.cursor].location
+		}
+		// The search bar filters the new items
+		panel.searchedElements = nil
+		m.readPanelElements(panel)
+		m.keepCursorOn(panel, cursorLocation)
+	}
+}
+
+func (m *model) toggleFlatten() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.flatten = !panel.flatten
+	if !panel.flatten {
+		panel.stopFlatWalk()
+	}
+	if panel.finder.active {
+		return
+	}
+	location := panel.location
+	if len(panel.element) > 0 {
+		location = panel.element[panel.cursor].location
+	}
+	m.reloadPanelElements(panel, location)
+}
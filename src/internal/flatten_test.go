@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func Test_returnFlatElements(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"a/b", ".hidden"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+	}
+	for _, f := range []string{"a/b/deep.jpg", "a/x.jpg", "top.txt", ".hidden/h.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, f), nil, 0644))
+	}
+	sortOptions := defaultFilePanel(dir).sortOptions.data
+	names := func(elements []element) []string {
+		var result []string
+		for _, e := range elements {
+			result = append(result, e.name)
+		}
+		return result
+	}
+
+	flat := func(displayDotFile bool, maxDepth int) []element {
+		elements, truncated := returnFlatElements(context.Background(), dir, displayDotFile, sortOptions, maxDepth, nil, nil)
+		assert.False(t, truncated)
+		return elements
+	}
+
+	elements := flat(false, 0)
+	assert.Equal(t, []string{"a", filepath.Join("a", "b"), filepath.Join("a", "b", "deep.jpg"),
+		filepath.Join("a", "x.jpg"), "top.txt"}, names(elements))
+	assert.Equal(t, filepath.Join(dir, "a", "b", "deep.jpg"), elements[2].location)
+
+	assert.Equal(t, []string{"a", filepath.Join("a", "b"), filepath.Join("a", "x.jpg"), "top.txt"},
+		names(flat(false, 2)))
+	assert.Contains(t, names(flat(true, 0)), filepath.Join(".hidden", "h.txt"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	elements, _ = returnFlatElements(ctx, dir, false, sortOptions, 0, nil, nil)
+	assert.Empty(t, elements, "a cancelled walk stops")
+}
+
+func TestModel_Flatten(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"cam1", "cam2/day1"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+	}
+	for _, f := range []string{"cam1/a.jpg", "cam2/day1/b.jpg", "cam2/notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, f), nil, 0644))
+	}
+	m := defaultModelConfig(false, true, []string{dir})
+	panel := &m.fileModel.filePanels[0]
+	panel.focusType = focus
+	m.getFilePanelItems()
+	require.Len(t, panel.element, 2)
+
+	// Items are walked in the background
+	panel.cursor = 1
+	m.toggleFlatten()
+	require.True(t, panel.flatten)
+	assert.Empty(t, panel.element)
+	assert.Contains(t, panel.emptyMessage(), "Listing...")
+	receiveFlatElements := func() {
+		t.Helper()
+		m.handleChannelMessage(receiveMessage(t, sendFlatElements))
+	}
+	receiveFlatElements()
+	assert.Len(t, panel.element, 6)
+	assert.Equal(t, filepath.Join(dir, "cam2"), panel.element[panel.cursor].location, "the cursor stays on the item")
+
+	// The walk is reused while the query changes, and listed meanwhile when
+	// the directories change
+	panel.searchMode = exactMatch
+	panel.searchBar.SetValue("cam2")
+	m.getFilePanelItems()
+	assert.Len(t, panel.element, 4)
+	panel.markListingStale()
+	m.getFilePanelItems()
+	assert.Len(t, panel.element, 4)
+	receiveFlatElements()
+	assert.Len(t, panel.element, 4)
+
+	panel.searchBar.SetValue("")
+
+	// Select all the images in nested folders
+	panel.searchMode = globMatch
+	panel.searchBar.SetValue("*.jpg")
+	m.getFilePanelItems()
+	require.Len(t, panel.element, 2)
+	m.changeFilePanelMode()
+	m.selectAllItem()
+	assert.ElementsMatch(t, []string{filepath.Join(dir, "cam1", "a.jpg"), filepath.Join(dir, "cam2", "day1", "b.jpg")},
+		panel.selected)
+
+	// Rename keeps the item in its directory
+	m.changeFilePanelMode()
+	panel.cursor = 1
+	m.panelItemRename()
+	assert.Equal(t, "b.jpg", panel.rename.Value())
+	panel.rename.SetValue("c.jpg")
+	m.confirmRename()
+	assert.FileExists(t, filepath.Join(dir, "cam2", "day1", "c.jpg"))
+
+	panel.searchBar.SetValue("")
+	common.Config.FlattenMaxDepth = 1
+	defer func() { common.Config.FlattenMaxDepth = 0 }()
+	panel.markListingStale()
+	m.getFilePanelItems()
+	receiveFlatElements()
+	assert.Len(t, panel.element, 2)
+
+	m.toggleFlatten()
+	assert.False(t, panel.flatten)
+}
@@ -0,0 +1,202 @@
+package frecency
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+// When the sum of all ranks exceeds this, ranks are scaled down and entries
+// with a low rank are forgotten. Same as zoxide's default _ZO_MAXAGE
+const maxAge = 10000
+
+type entry struct {
+	Path         string  `json:"path"`
+	Rank         float64 `json:"rank"`
+	LastAccessed int64   `json:"last_accessed"`
+}
+
+// Database of visited directories ranked by frecency, a combination of how
+// often and how recently they were visited. A nil *Database stays empty and
+// is never saved.
+type Database struct {
+	mu      sync.Mutex
+	file    string
+	entries map[string]*entry
+	// Only overridden in tests
+	now func() time.Time
+}
+
+func New(file string) *Database {
+	return &Database{
+		file:    file,
+		entries: make(map[string]*entry),
+		now:     time.Now,
+	}
+}
+
+// Load the database saved in file, empty when it can't be read
+func Load(file string) *Database {
+	db := New(file)
+	var entries []entry
+	if !utils.ReadJSONFile(file, &entries) {
+		return db
+	}
+	for _, e := range entries {
+		db.entries[e.Path] = &e
+	}
+	return db
+}
+
+func (db *Database) Save() error {
+	if db == nil {
+		return nil
+	}
+	db.mu.Lock()
+	entries := make([]entry, 0, len(db.entries))
+	for _, e := range db.entries {
+		entries = append(entries, *e)
+	}
+	db.mu.Unlock()
+	return utils.WriteJSONFile(db.file, entries)
+}
+
+func (db *Database) Len() int {
+	if db == nil {
+		return 0
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return len(db.entries)
+}
+
+// Record a visit of path
+func (db *Database) Add(path string) {
+	if db == nil || path == "" {
+		return
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.addWithRank(path, 1, db.now().Unix())
+}
+
+// Add rank to the entry of path. Caller must hold db.mu
+func (db *Database) addWithRank(path string, rank float64, lastAccessed int64) {
+	if e, ok := db.entries[path]; ok {
+		e.Rank += rank
+		e.LastAccessed = max(e.LastAccessed, lastAccessed)
+	} else {
+		db.entries[path] = &entry{Path: path, Rank: rank, LastAccessed: lastAccessed}
+	}
+	db.age()
+}
+
+// Scale down ranks once their sum exceeds maxAge, forgetting rarely used
+// entries. Caller must hold db.mu
+func (db *Database) age() {
+	var total float64
+	for _, e := range db.entries {
+		total += e.Rank
+	}
+	if total <= maxAge {
+		return
+	}
+	factor := 0.9 * maxAge / total
+	for path, e := range db.entries {
+		e.Rank *= factor
+		if e.Rank < 1 {
+			delete(db.entries, path)
+		}
+	}
+}
+
+func (db *Database) Remove(path string) {
+	if db == nil {
+		return
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	delete(db.entries, path)
+}
+
+// Frecency score of e at time now
+func (e *entry) score(now int64) float64 {
+	duration := now - e.LastAccessed
+	switch {
+	case duration < int64(time.Hour.Seconds()):
+		return e.Rank * 4
+	case duration < int64((24 * time.Hour).Seconds()):
+		return e.Rank * 2
+	case duration < int64((7 * 24 * time.Hour).Seconds()):
+		return e.Rank / 2
+	default:
+		return e.Rank / 4
+	}
+}
+
+// Paths of all existing directories in the database, from the highest to the
+// lowest score
+func (db *Database) Ranked() []string {
+	if db == nil {
+		return nil
+	}
+	db.mu.Lock()
+	now := db.now().Unix()
+	entries := make([]entry, 0, len(db.entries))
+	for _, e := range db.entries {
+		entries = append(entries, *e)
+	}
+	db.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		si, sj := entries[i].score(now), entries[j].score(now)
+		if si != sj {
+			return si > sj
+		}
+		return entries[i].Path < entries[j].Path
+	})
+	paths := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if info, err := os.Stat(e.Path); err == nil && info.IsDir() {
+			paths = append(paths, e.Path)
+		}
+	}
+	return paths
+}
+
+// Highest ranked directory matching all keywords, the way zoxide does it.
+// Keywords are matched case insensitively and in order, and the last keyword
+// must match the last component of the path.
+func (db *Database) Query(keywords []string) (string, bool) {
+	for _, path := range db.Ranked() {
+		if matchKeywords(path, keywords) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func matchKeywords(path string, keywords []string) bool {
+	if len(keywords) == 0 {
+		return false
+	}
+	lowerPath := strings.ToLower(path)
+	lastKeyword := strings.ToLower(keywords[len(keywords)-1])
+	if !strings.Contains(strings.ToLower(filepath.Base(path)), lastKeyword) {
+		return false
+	}
+	for _, keyword := range keywords {
+		keyword = strings.ToLower(keyword)
+		index := strings.Index(lowerPath, keyword)
+		if index == -1 {
+			return false
+		}
+		lowerPath = lowerPath[index+len(keyword):]
+	}
+	return true
+}
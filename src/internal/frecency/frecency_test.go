@@ -0,0 +1,152 @@
+package frecency
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDatabase(t *testing.T, now time.Time) (*Database, string) {
+	t.Helper()
+	dir := t.TempDir()
+	db := New(filepath.Join(dir, "frecency.json"))
+	db.now = func() time.Time { return now }
+	return db, dir
+}
+
+func makeDirs(t *testing.T, root string, names ...string) []string {
+	t.Helper()
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(paths[i], 0755))
+	}
+	return paths
+}
+
+func TestDatabase_Ranked(t *testing.T) {
+	now := time.Now()
+	db, root := testDatabase(t, now)
+	dirs := makeDirs(t, root, "often", "recent", "old")
+
+	for range 3 {
+		db.Add(dirs[0])
+	}
+	db.Add(dirs[1])
+	db.Add(dirs[2])
+	db.Add(filepath.Join(root, "missing"))
+	// Visited often, but a long time ago
+	db.entries[dirs[0]].LastAccessed = now.Add(-30 * 24 * time.Hour).Unix()
+	db.entries[dirs[2]].LastAccessed = now.Add(-30 * 24 * time.Hour).Unix()
+
+	assert.Equal(t, []string{dirs[1], dirs[0], dirs[2]}, db.Ranked(),
+		"missing directories should not be listed")
+
+	db.Remove(dirs[1])
+	assert.Equal(t, []string{dirs[0], dirs[2]}, db.Ranked())
+}
+
+func TestDatabase_Query(t *testing.T) {
+	db, root := testDatabase(t, time.Now())
+	dirs := makeDirs(t, root, "projects/api", "projects/web/API-docs", "api/projects")
+	for i, dir := range dirs {
+		for range i + 1 {
+			db.Add(dir)
+		}
+	}
+
+	testdata := []struct {
+		name     string
+		keywords []string
+		expected string
+		found    bool
+	}{
+		{"Last keyword must match the last component", []string{"projects"}, dirs[2], true},
+		{"Case insensitive and highest score wins", []string{"api"}, dirs[1], true},
+		{"Keywords match in order", []string{"proj", "api"}, dirs[1], true},
+		{"Keywords in wrong order", []string{"api", "web"}, "", false},
+		{"No match", []string{"nothing"}, "", false},
+		{"No keywords", nil, "", false},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			path, found := db.Query(tt.keywords)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+}
+
+func TestDatabase_Aging(t *testing.T) {
+	db, _ := testDatabase(t, time.Now())
+	db.entries["/rare"] = &entry{Path: "/rare", Rank: 1}
+	db.entries["/often"] = &entry{Path: "/often", Rank: maxAge}
+	db.Add("/often")
+	assert.Equal(t, 1, db.Len(), "low ranked entries should be forgotten")
+	assert.Less(t, db.entries["/often"].Rank, float64(maxAge))
+}
+
+func TestDatabase_SaveAndLoad(t *testing.T) {
+	db, root := testDatabase(t, time.Now())
+	dirs := makeDirs(t, root, "a", "b")
+	db.Add(dirs[0])
+	db.Add(dirs[1])
+	db.Add(dirs[1])
+	require.NoError(t, db.Save())
+
+	loaded := Load(db.file)
+	assert.Equal(t, []string{dirs[1], dirs[0]}, loaded.Ranked())
+
+	assert.Equal(t, 0, Load(filepath.Join(root, "missing.json")).Len())
+
+	var nilDB *Database
+	nilDB.Add(dirs[0])
+	require.NoError(t, nilDB.Save())
+	assert.Empty(t, nilDB.Ranked())
+}
+
+func zoxideDatabase(t *testing.T, entries []entry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	write := func(v any) {
+		require.NoError(t, binary.Write(&buf, binary.LittleEndian, v))
+	}
+	write(uint32(zoxideFormatVersion))
+	write(uint64(len(entries)))
+	for _, e := range entries {
+		write(uint64(len(e.Path)))
+		buf.WriteString(e.Path)
+		write(e.Rank)
+		write(uint64(e.LastAccessed))
+	}
+	return buf.Bytes()
+}
+
+func TestDatabase_ImportZoxide(t *testing.T) {
+	now := time.Now()
+	db, root := testDatabase(t, now)
+	dirs := makeDirs(t, root, "a", "b")
+	db.Add(dirs[0])
+
+	zoxideFile := filepath.Join(root, "db.zo")
+	require.NoError(t, os.WriteFile(zoxideFile, zoxideDatabase(t, []entry{
+		{Path: dirs[0], Rank: 2, LastAccessed: now.Unix() - 10},
+		{Path: dirs[1], Rank: 5, LastAccessed: now.Unix()},
+	}), 0644))
+	require.NoError(t, db.ImportZoxide(zoxideFile))
+
+	assert.InDelta(t, 3, db.entries[dirs[0]].Rank, 0.001)
+	assert.Equal(t, now.Unix(), db.entries[dirs[0]].LastAccessed)
+	assert.Equal(t, []string{dirs[1], dirs[0]}, db.Ranked())
+
+	_, err := parseZoxideDatabase([]byte{1, 0, 0, 0})
+	require.Error(t, err, "unsupported version should fail")
+	_, err = parseZoxideDatabase(zoxideDatabase(t, []entry{{Path: "/a", Rank: 1}})[:20])
+	require.Error(t, err, "truncated database should fail")
+}
@@ -0,0 +1,94 @@
+package frecency
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+)
+
+// Only this version of zoxide's database format is supported. It is used since zoxide v0.8.0
+const zoxideFormatVersion = 3
+
+// Location of zoxide's database, honoring _ZO_DATA_DIR
+func ZoxideDatabaseFile() string {
+	if dir := os.Getenv("_ZO_DATA_DIR"); dir != "" {
+		return filepath.Join(dir, "db.zo")
+	}
+	return filepath.Join(xdg.DataHome, "zoxide", "db.zo")
+}
+
+// Import all entries of zoxide's database stored in file. Ranks of paths already
+// in the database are added up
+func (db *Database) ImportZoxide(file string) error {
+	if db == nil {
+		return nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("error reading zoxide database: %w", err)
+	}
+	entries, err := parseZoxideDatabase(data)
+	if err != nil {
+		return fmt.Errorf("error parsing zoxide database: %w", err)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, e := range entries {
+		db.addWithRank(e.Path, e.Rank, e.LastAccessed)
+	}
+	return nil
+}
+
+// zoxide stores its database with bincode: a little endian u32 version followed
+// by a u64 count of entries. Each entry is a u64 length prefixed path, a f64
+// rank and a u64 last accessed unix timestamp
+func parseZoxideDatabase(data []byte) ([]entry, error) {
+	reader := bytes.NewReader(data)
+	var version uint32
+	if err := binary.Read(reader, binary.LittleEndian, &version); err != nil {
+		return nil, err
+	}
+	if version != zoxideFormatVersion {
+		return nil, fmt.Errorf("unsupported database version %d", version)
+	}
+
+	var count uint64
+	if err := binary.Read(reader, binary.LittleEndian, &count); err != nil {
+		return nil, err
+	}
+	var entries []entry
+	for range count {
+		var pathLen uint64
+		if err := binary.Read(reader, binary.LittleEndian, &pathLen); err != nil {
+			return nil, err
+		}
+		if pathLen > uint64(reader.Len()) {
+			return nil, errors.New("path length exceeds database size")
+		}
+		path := make([]byte, pathLen)
+		if _, err := io.ReadFull(reader, path); err != nil {
+			return nil, err
+		}
+		var rank float64
+		var lastAccessed uint64
+		if err := binary.Read(reader, binary.LittleEndian, &rank); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(reader, binary.LittleEndian, &lastAccessed); err != nil {
+			return nil, err
+		}
+		if lastAccessed > math.MaxInt64 {
+			return nil, errors.New("invalid last accessed time")
+		}
+		entries = append(entries, entry{Path: string(path), Rank: rank, LastAccessed: int64(lastAccessed)})
+	}
+	return entries, nil
+}
@@ -11,7 +11,6 @@ import (
 	"regexp"
 	"runtime"
 	"slices"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +19,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
 
 	"github.com/lithammer/shortuuid"
 )
@@ -58,135 +58,90 @@ func returnFocusType(focusPanel focusPanelType) filePanelFocusType {
 	return secondFocus
 }
 
-func returnDirElement(location string, displayDotFile bool, sortOptions sortOptionsModelData) []element {
+func returnDirElement(location string, displayDotFile bool, sortOptions sortOptionsModelData,
+	sizes *dirsize.Cache) []element {
 	dirEntries, err := os.ReadDir(location)
 	if err != nil {
 		slog.Error("Error while return folder element function", "error", err)
 		return nil
 	}
 
-	dirEntries = slices.DeleteFunc(dirEntries, func(e os.DirEntry) bool {
+	entries := make([]listedEntry, 0, len(dirEntries))
+	for _, entry := range dirEntries {
+		entries = append(entries, listedEntry{DirEntry: entry, dir: location, name: entry.Name()})
+	}
+	return sortedElements(entries, displayDotFile, sortOptions, sizes)
+}
+
+// Entry of a listing, which can come from a subdirectory of the listed
+// location, like in flatten mode
+type listedEntry struct {
+	os.DirEntry
+	// Directory containing the entry
+	dir string
+	// Name shown in the panel
+	name string
+	info os.FileInfo
+	// Keys of the size and date sorts, see fillSortKeys
+	sortSize int64
+	sortTime time.Time
+}
+
+// Sort entries and return them as elements. Hidden entries are skipped
+// unless displayDotFile is on. Directories sorted by size are sorted with
+// their size in sizes
+func sortedElements(entries []listedEntry, displayDotFile bool, sortOptions sortOptionsModelData,
+	sizes *dirsize.Cache) []element {
+	entries = slices.DeleteFunc(entries, func(e listedEntry) bool {
 		// Entries not needed to be considered
-		_, err := e.Info()
-		return err != nil || (strings.HasPrefix(e.Name(), ".") && !displayDotFile)
+		return strings.HasPrefix(e.Name(), ".") && !displayDotFile
+	})
+	// Entries whose stats can't be read are skipped
+	for i := range entries {
+		if info, err := entries[i].Info(); err == nil {
+			entries[i].info = info
+		}
+	}
+	entries = slices.DeleteFunc(entries, func(e listedEntry) bool {
+		return e.info == nil
 	})
 
 	// No files/directoes to process
-	if len(dirEntries) == 0 {
+	if len(entries) == 0 {
 		return nil
 	}
 
-	// Sort files
-	var order func(i, j int) bool
-	reversed := sortOptions.reversed
-
-	// Todo : These strings should not be hardcoded here, but defined as constants
-	switch sortOptions.options[sortOptions.selected] {
-	case "Name":
-		order = func(i, j int) bool {
-			// One of them is a directory, and other is not
-			if dirEntries[i].IsDir() != dirEntries[j].IsDir() {
-				return dirEntries[i].IsDir()
-			}
-			if common.Config.CaseSensitiveSort {
-				return dirEntries[i].Name() < dirEntries[j].Name() != reversed
-			}
-			return strings.ToLower(dirEntries[i].Name()) < strings.ToLower(dirEntries[j].Name()) != reversed
-		}
-	case "Size":
-		order = func(i, j int) bool {
-			// Directories at the top sorted by direct child count (not recursive)
-			// Files sorted by size
-
-			// One of them is a directory, and other is not
-			if dirEntries[i].IsDir() != dirEntries[j].IsDir() {
-				return dirEntries[i].IsDir()
-			}
-
-			// This needs to be improved, and we should sort by actual size only
-			// Repeated recursive read would be slow, so we could cache
-			if dirEntries[i].IsDir() && dirEntries[j].IsDir() {
-				filesI, err := os.ReadDir(filepath.Join(location, dirEntries[i].Name()))
-				// No need of early return, we only call len() on filesI, so nil would
-				// just result in 0
-				if err != nil {
-					slog.Error("Error when reading directory during sort", "error", err)
-				}
-				filesJ, err := os.ReadDir(filepath.Join(location, dirEntries[j].Name()))
-				if err != nil {
-					slog.Error("Error when reading directory during sort", "error", err)
-				}
-				return len(filesI) < len(filesJ) != reversed
-			}
-			// No need for err check, we already filtered out dirEntries with err != nil in Info() call
-			fileInfoI, _ := dirEntries[i].Info()
-			fileInfoJ, _ := dirEntries[j].Info()
-			return fileInfoI.Size() < fileInfoJ.Size() != reversed
-		}
-	case "Date Modified":
-		order = func(i, j int) bool {
-			// No need for err check, we already filtered out dirEntries with err != nil in Info() call
-			fileInfoI, _ := dirEntries[i].Info()
-			fileInfoJ, _ := dirEntries[j].Info()
-			return fileInfoI.ModTime().After(fileInfoJ.ModTime()) != reversed
-		}
-	}
-
-	sort.Slice(dirEntries, order)
+	sortEntries(entries, sortOptions, sizes)
 	// Preallocate for efficiency
-	directoryElement := make([]element, 0, len(dirEntries))
-	for _, item := range dirEntries {
+	directoryElement := make([]element, 0, len(entries))
+	for _, item := range entries {
 		directoryElement = append(directoryElement, element{
-			name:      item.Name(),
+			name:      item.name,
 			directory: item.IsDir(),
-			location:  filepath.Join(location, item.Name()),
+			location:  filepath.Join(item.dir, item.Name()),
+			info:      item.info,
 		})
 	}
 	return directoryElement
 }
 
-func returnDirElementBySearchString(location string, displayDotFile bool, searchString string) []element {
-	items, err := os.ReadDir(location)
+// Return the elements matching searchString, in the same order. The file
+// panel runs fuzzy searches in the background instead, see startFuzzySearch
+func filterElements(elements []element, searchString string, mode matchMode) []element {
+	match, err := newNameMatcher(mode, searchString)
 	if err != nil {
-		slog.Error("Error while return folder element function", "error", err)
-		return []element{}
-	}
-
-	if len(items) == 0 {
 		return []element{}
 	}
-
-	folderElementMap := map[string]element{}
-	fileAndDirectories := []string{}
-
-	for _, item := range items {
-		fileInfo, err := item.Info()
-		if err != nil {
-			continue
-		}
-		if !displayDotFile && strings.HasPrefix(fileInfo.Name(), ".") {
-			continue
-		}
-
-		folderElementLocation := filepath.Join(location, item.Name())
-
-		fileAndDirectories = append(fileAndDirectories, item.Name())
-		folderElementMap[item.Name()] = element{
-			name:      item.Name(),
-			directory: item.IsDir(),
-			location:  folderElementLocation,
-		}
+	names := make([]string, len(elements))
+	for i, item := range elements {
+		names[i] = item.name
 	}
-	// https://github.com/reinhrst/fzf-lib/blob/main/core.go#L43
-	// No sorting needed. fzf.DefaultOptions() already return values ordered on Score
-	fzfResults := utils.FzfSearch(searchString, fileAndDirectories)
-	dirElement := make([]element, 0, len(fzfResults))
-	for _, item := range fzfResults {
-		resultItem := folderElementMap[item.Key]
-		dirElement = append(dirElement, resultItem)
+	matched := match(names)
+	filtered := make([]element, 0, len(matched))
+	for _, i := range matched {
+		filtered = append(filtered, elements[i])
 	}
-
-	return dirElement
+	return filtered
 }
 
 func panelElementHeight(mainPanelHeight int) int {
@@ -324,10 +279,15 @@ func (m *model) returnMetaData() {
 
 	if fileInfo.IsDir() {
 		m.fileMetaData.metaData = append(m.fileMetaData.metaData, [2]string{"Name", fileInfo.Name()})
-		if m.focusPanel == metadataFocus {
-			// Todo : Calling dirSize() could be expensive for large directories, as it recursively
-			// walks the entire tree. Consider lazy loading, caching, or an async approach to avoid UI lockups.
-			m.fileMetaData.metaData = append(m.fileMetaData.metaData, [2]string{"Size", common.FormatFileSize(dirSize(filePath))})
+		// Walking the directory is costly, its size is only computed when
+		// the metadata is focused or the panel needs it anyway. It is filled
+		// in by handleDirSizes once computed
+		if m.focusPanel == metadataFocus || panel.needsDirSizes() {
+			if size, ok := m.dirSizes.Size(filePath, fileInfo.ModTime()); ok {
+				m.fileMetaData.metaData = append(m.fileMetaData.metaData, [2]string{"Size", common.FormatFileSize(size)})
+			} else {
+				m.fileMetaData.metaData = append(m.fileMetaData.metaData, [2]string{"Size", dirSizeCalculating})
+			}
 		}
 		m.fileMetaData.metaData = append(m.fileMetaData.metaData, [2]string{"Date Modified", fileInfo.ModTime().String()})
 		m.fileMetaData.metaData = append(m.fileMetaData.metaData, [2]string{"Permissions", fileInfo.Mode().String()})
@@ -398,28 +358,6 @@ func calculateMD5Checksum(filePath string) (string, error) {
 	return checksum, nil
 }
 
-// Get directory total size
-func dirSize(path string) int64 {
-	var size int64
-	// Its named walkErr to prevent shadowing
-	walkErr := filepath.WalkDir(path, func(_ string, entry os.DirEntry, err error) error {
-		if err != nil {
-			slog.Error("Dir size function error", "error", err)
-		}
-		if !entry.IsDir() {
-			info, infoErr := entry.Info()
-			if infoErr == nil {
-				size += info.Size()
-			}
-		}
-		return err
-	})
-	if walkErr != nil {
-		slog.Error("errors during WalkDir", "error", walkErr)
-	}
-	return size
-}
-
 // Count how many file in the directory
 func countFiles(dirPath string) (int, error) {
 	count := 0
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"context"
+	"slices"
+
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+// Start a fuzzy search of the search bar query among elements, the items of
+// the panel location, in the background. Nothing is done if the same search
+// was already started, and the previous search is cancelled otherwise.
+func (m *model) startFuzzySearch(panel *filePanel, elements []element) {
+	search := &panel.fuzzySearch
+	query := panel.searchBar.Value()
+	names := make([]string, len(elements))
+	for i, item := range elements {
+		names[i] = item.name
+	}
+	sameNames := search.searcher != nil && slices.Equal(search.searcher.Haystack(), names)
+	if sameNames && search.location == panel.location && search.query == query {
+		return
+	}
+
+	if search.cancel != nil {
+		search.cancel()
+	}
+	// Don't keep listing the matches of the previous location until the
+	// results come in
+	if search.location != panel.location {
+		panel.element = nil
+	}
+	searcher := search.searcher
+	if !sameNames {
+		if searcher != nil {
+			go searcher.End()
+		}
+		searcher = utils.NewFzfSearcher(names)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.lastFuzzySearchID++
+	*search = fuzzySearch{
+		searcher: searcher,
+		location: panel.location,
+		query:    query,
+		id:       m.lastFuzzySearchID,
+		cancel:   cancel,
+	}
+	go runFuzzySearch(ctx, search.id, searcher, elements, query)
+}
+
+func runFuzzySearch(ctx context.Context, id int, searcher *utils.FzfSearcher, elements []element, query string) {
+	matches, err := searcher.Search(ctx, query)
+	if err != nil {
+		return
+	}
+	results := make([]element, 0, len(matches))
+	for _, match := range matches {
+		item := elements[match.HayIndex]
+		item.matchPositions = match.Positions
+		results = append(results, item)
+	}
+	message := channelMessage{
+		messageType:        sendFuzzySearchResults,
+		fuzzySearchResults: fuzzySearchResults{id: id, elements: results},
+	}
+	select {
+	case channel <- message:
+	case <-ctx.Done():
+	}
+}
+
+// Cancel the running fuzzy search, if any, and release the fzf instance
+func (panel *filePanel) stopFuzzySearch() {
+	search := &panel.fuzzySearch
+	if search.cancel != nil {
+		search.cancel()
+	}
+	if search.searcher != nil {
+		go search.searcher.End()
+	}
+	*search = fuzzySearch{}
+}
+
+// List the matches of the latest fuzzy search in the panel it was started in
+func (m *model) handleFuzzySearchResults(results fuzzySearchResults) {
+	for i := range m.fileModel.filePanels {
+		panel := &m.fileModel.filePanels[i]
+		if panel.fuzzySearch.cancel == nil || panel.fuzzySearch.id != results.id {
+			continue
+		}
+		panel.element = results.elements
+		if panel.cursor >= len(panel.element) {
+			panel.cursor = max(0, len(panel.element)-1)
+		}
+		if panel.render > panel.cursor {
+			panel.render = panel.cursor
+		}
+	}
+}
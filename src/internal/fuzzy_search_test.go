@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModel_FuzzySearch(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"app.log", "b.log", "c.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+	m := defaultModelConfig(false, true, []string{dir})
+	panel := &m.fileModel.filePanels[0]
+	panel.focusType = focus
+
+	receiveResults := func() {
+		m.handleChannelMessage(receiveMessage(t, sendFuzzySearchResults))
+	}
+
+	panel.searchBar.SetValue("alog")
+	m.getFilePanelItems()
+	receiveResults()
+	require.Len(t, panel.element, 1)
+	assert.Equal(t, "app.log", panel.element[0].name)
+	assert.ElementsMatch(t, []int{0, 4, 5, 6}, panel.element[0].matchPositions)
+
+	searcher := panel.fuzzySearch.searcher
+	// Same query on the same items, no new search
+	id := panel.fuzzySearch.id
+	m.getFilePanelItems()
+	assert.Equal(t, id, panel.fuzzySearch.id)
+
+	// New query reuses the fzf instance of the same items
+	panel.searchBar.SetValue("log")
+	m.getFilePanelItems()
+	assert.Same(t, searcher, panel.fuzzySearch.searcher)
+	receiveResults()
+	assert.Len(t, panel.element, 2)
+
+	// Results of a stale search are dropped
+	m.handleFuzzySearchResults(fuzzySearchResults{id: id, elements: nil})
+	assert.Len(t, panel.element, 2)
+
+	// Items are kept while only the query changes, until they are dropped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "d.log"), nil, 0644))
+	panel.searchBar.SetValue("lo")
+	m.readPanelElements(panel)
+	receiveResults()
+	assert.Len(t, panel.element, 2)
+	panel.markListingStale()
+	m.readPanelElements(panel)
+	receiveResults()
+	assert.Len(t, panel.element, 3)
+
+	panel.searchBar.SetValue("")
+	m.getFilePanelItems()
+	assert.Nil(t, panel.fuzzySearch.searcher)
+	assert.Len(t, panel.element, 4)
+}
@@ -0,0 +1,254 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lithammer/shortuuid"
+	"github.com/yorukot/ansichroma"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/gitstatus"
+)
+
+var errNotInWorkTree = errors.New("not in a git work tree")
+
+// Git command run on items by an action
+type gitAction struct {
+	// Shown in the process bar
+	name string
+	// Arguments of git before the paths of the items
+	args []string
+	// Run on the tracked files below the items, as git fails on paths that
+	// only contain untracked files
+	trackedOnly bool
+}
+
+func gitStageAction() gitAction {
+	// -A stages deletions too
+	return gitAction{name: "Stage", args: []string{"add", "-A", "--"}}
+}
+
+func gitUnstageAction() gitAction {
+	return gitAction{name: "Unstage", args: []string{"reset", "-q", "--"}}
+}
+
+func gitDiscardAction() gitAction {
+	// Untracked items are left alone
+	return gitAction{name: "Discard", args: []string{"restore", "--"}, trackedOnly: true}
+}
+
+// Diff of items, previewed in place of the item under the cursor
+type gitDiff struct {
+	location string
+	content  string
+	err      error
+}
+
+// Items that git actions and tags apply to: the selected items in select
+// mode, the item under the cursor otherwise
+func (panel *filePanel) actionTargets() []string {
+	if panel.panelMode == selectMode && len(panel.selected) > 0 {
+		return slices.Clone(panel.selected)
+	}
+	if len(panel.element) == 0 {
+		return nil
+	}
+	return []string{panel.element[panel.cursor].location}
+}
+
+// Paths grouped by the root of their work tree, sorted by root. Paths outside
+// of work trees have an empty root
+func groupByWorkTree(paths []string) ([]string, map[string][]string) {
+	groups := make(map[string][]string)
+	for _, path := range paths {
+		root := gitstatus.FindRoot(filepath.Dir(path))
+		groups[root] = append(groups[root], path)
+	}
+	roots := make([]string, 0, len(groups))
+	for root := range groups {
+		roots = append(roots, root)
+	}
+	slices.Sort(roots)
+	return roots, groups
+}
+
+// Run git in the work tree at root. Errors include what git printed
+func runGit(root string, args ...string) (string, error) {
+	output, err := exec.Command("git", append([]string{"-C", root}, args...)...).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return string(output), err
+}
+
+// Tracked files at or below paths, relative to root
+func trackedFiles(root string, paths []string) ([]string, error) {
+	output, err := runGit(root, append([]string{"ls-files", "-z", "--"}, paths...)...)
+	if err != nil {
+		return nil, err
+	}
+	return strings.FieldsFunc(output, func(r rune) bool { return r == 0 }), nil
+}
+
+// Run the action on targets, in the work trees containing them. Progress is
+// shown in the process bar. targets are read from the focused panel before
+// the action is started in the background
+func (m *model) runGitAction(action gitAction, targets []string) {
+	if len(targets) == 0 {
+		return
+	}
+
+	id := shortuuid.New()
+	newProcess := process{
+		name:     icon.GitBranch + icon.Space + action.name + " " + filepath.Base(targets[0]),
+		progress: common.GenerateDefaultProgress(),
+		state:    inOperation,
+		total:    len(targets),
+	}
+	m.processBarModel.process[id] = newProcess
+	message := channelMessage{
+		messageID:       id,
+		messageType:     sendProcess,
+		processNewState: newProcess,
+	}
+	channel <- message
+
+	p := newProcess
+	roots, groups := groupByWorkTree(targets)
+	for _, root := range roots {
+		err := errNotInWorkTree
+		if root != "" {
+			err = applyGitAction(root, action, groups[root])
+			m.gitStatuses.Invalidate(root)
+		}
+		if err != nil {
+			slog.Error("Error while running git action", "action", action.name, "root", root, "error", err)
+			p.state = failure
+			break
+		}
+		p.done += len(groups[root])
+	}
+	if p.state != failure {
+		p.state = successful
+		p.doneTime = time.Now()
+	}
+	message.processNewState = p
+	channel <- message
+}
+
+// Run the action on paths of the work tree at root
+func applyGitAction(root string, action gitAction, paths []string) error {
+	if action.trackedOnly {
+		var err error
+		if paths, err = trackedFiles(root, paths); err != nil || len(paths) == 0 {
+			return err
+		}
+	}
+	_, err := runGit(root, append(action.args, paths...)...)
+	return err
+}
+
+func (m *model) gitDiscardWarn() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	if len(panel.actionTargets()) == 0 {
+		return
+	}
+	channel <- channelMessage{
+		messageID:   shortuuid.New(),
+		messageType: sendWarnModal,
+		warnModal: warnModal{
+			open:     true,
+			title:    "Are you sure you want to discard the changes",
+			content:  "Changes that are not staged will be lost. Untracked files are kept.",
+			warnType: confirmGitDiscard,
+		},
+	}
+}
+
+// Git action targets of the focused panel, along with the location the diff
+// of the targets is previewed for: the item under the cursor, or the first
+// target when nothing is listed
+func (m *model) gitDiffTargets() ([]string, string) {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	targets := panel.actionTargets()
+	if len(targets) == 0 {
+		return nil, ""
+	}
+	if len(panel.element) == 0 {
+		return targets, targets[0]
+	}
+	return targets, panel.element[panel.cursor].location
+}
+
+// Read the diff of targets, staged changes first. It is previewed in place of
+// the item at location
+func readGitDiff(targets []string, location string) {
+	if len(targets) == 0 {
+		return
+	}
+	diff := gitDiff{location: location}
+	roots, groups := groupByWorkTree(targets)
+	var content strings.Builder
+	for _, root := range roots {
+		if root == "" {
+			continue
+		}
+		for _, args := range [][]string{{"diff", "--cached", "--"}, {"diff", "--"}} {
+			output, err := runGit(root, append(args, groups[root]...)...)
+			if err != nil {
+				slog.Error("Error while reading git diff", "root", root, "error", err)
+				diff.err = err
+			}
+			content.WriteString(output)
+		}
+	}
+	if len(groups[""]) == len(targets) {
+		diff.err = errNotInWorkTree
+	}
+	diff.content = content.String()
+	channel <- channelMessage{messageType: sendGitDiff, gitDiff: diff}
+}
+
+// Preview the diff, opening the preview panel if needed
+func (m *model) handleGitDiff(diff gitDiff) {
+	m.fileModel.filePreview.gitDiff = &diff
+	if !m.fileModel.filePreview.open {
+		m.toggleFilePreviewPanel()
+	}
+}
+
+// Lines of the diff that fit in the preview, with syntax highlighting
+func gitDiffPreview(diff *gitDiff, height int, width int) string {
+	if diff.err != nil {
+		return "\n --- " + icon.Error + " " + common.MakePrintable(diff.err.Error()) + " ---"
+	}
+	if diff.content == "" {
+		return "\n --- No changes ---"
+	}
+	lines := strings.Split(strings.TrimSuffix(diff.content, "\n"), "\n")
+	lines = lines[:min(len(lines), height)]
+	for i, line := range lines {
+		lines[i] = common.MakePrintable(line)
+	}
+	content := strings.Join(lines, "\n")
+	background := ""
+	if !common.Config.TransparentBackground {
+		background = common.Theme.FilePanelBG
+	}
+	highlighted, err := ansichroma.HightlightString(content, "diff", common.Theme.CodeSyntaxHighlightTheme, background)
+	if err != nil {
+		slog.Error("Error render diff highlight", "error", err)
+	} else {
+		content = highlighted
+	}
+	return common.CheckAndTruncateLineLengths(content, width)
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run the git action on the focused panel and return the final state of its
+// process
+func runTestGitAction(t *testing.T, m *model, action gitAction) processState {
+	t.Helper()
+	m.runGitAction(action, m.fileModel.filePanels[m.filePanelFocusIndex].actionTargets())
+	require.Equal(t, inOperation, receiveMessage(t, sendProcess).processNewState.state)
+	return receiveMessage(t, sendProcess).processNewState.state
+}
+
+func TestModel_GitActions(t *testing.T) {
+	root := setupGitRepo(t)
+	committed := filepath.Join(root, "committed.txt")
+	require.NoError(t, os.WriteFile(committed, []byte("second\n"), 0644))
+	m := defaultModelConfig(false, true, []string{root})
+	TeaUpdate(&m, tea.WindowSizeMsg{Width: 150, Height: 40})
+	m.getFilePanelItems()
+	panel := &m.fileModel.filePanels[0]
+	// Directories are listed first
+	panel.cursor = 1
+	require.Equal(t, committed, panel.element[panel.cursor].location)
+
+	assert.Equal(t, successful, runTestGitAction(t, &m, gitStageAction()))
+	assert.Equal(t, "M  committed.txt\n", testGit(t, root, "status", "--porcelain", "--", "committed.txt"))
+	assert.Equal(t, successful, runTestGitAction(t, &m, gitUnstageAction()))
+	assert.Equal(t, " M committed.txt\n", testGit(t, root, "status", "--porcelain", "--", "committed.txt"))
+
+	// The diff is previewed until the cursor leaves the item
+	readGitDiff(m.gitDiffTargets())
+	m.handleChannelMessage(receiveMessage(t, sendGitDiff))
+	require.True(t, m.fileModel.filePreview.open)
+	assert.Contains(t, m.filePreviewPanelRender(), "+second")
+	panel.cursor = 0
+	m.updateFilePanelsState(nil, nil)
+	assert.Nil(t, m.fileModel.filePreview.gitDiff)
+
+	// Selected items are used in select mode, untracked ones are kept by discard
+	panel.panelMode = selectMode
+	panel.selected = []string{committed, filepath.Join(root, "sub")}
+	assert.Equal(t, successful, runTestGitAction(t, &m, gitDiscardAction()))
+	content, err := os.ReadFile(committed)
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", string(content))
+	assert.FileExists(t, filepath.Join(root, "sub", "new.txt"))
+
+	// The diff of selected items is read even when nothing is listed
+	panel.element = nil
+	targets, location := m.gitDiffTargets()
+	assert.Equal(t, panel.selected, targets)
+	assert.Equal(t, committed, location)
+
+	// Actions fail outside of work trees
+	m = defaultModelConfig(false, true, []string{t.TempDir()})
+	m.fileModel.filePanels[0].element = []element{{name: "file", location: filepath.Join(t.TempDir(), "file")}}
+	assert.Equal(t, failure, runTestGitAction(t, &m, gitStageAction()))
+}
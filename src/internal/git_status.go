@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"log/slog"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/gitstatus"
+)
+
+// Git statuses shown in the panels are read again when older, to catch
+// changes made with git itself
+const gitStatusMaxAge = 5 * time.Second
+
+func notifyGitStatus() {
+	channel <- channelMessage{messageType: sendGitStatus}
+}
+
+// Cache of the git statuses shown in the panels, or nil when they are
+// disabled or git is not installed
+func newGitStatusCache() *gitstatus.Cache {
+	if !common.Config.GitStatus {
+		return nil
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		slog.Debug("git is not installed, git status is not shown", "error", err)
+		return nil
+	}
+	return gitstatus.New(gitStatusMaxAge, notifyGitStatus)
+}
+
+// One letter marker of the state of an item, shown beside its name
+func gitStateMarker(state gitstatus.State) string {
+	switch state.Main() {
+	case gitstatus.Conflicted:
+		return common.GitConflictedStyle.Render("C")
+	case gitstatus.Modified:
+		return common.GitModifiedStyle.Render("M")
+	case gitstatus.Staged:
+		return common.GitStagedStyle.Render("S")
+	case gitstatus.Untracked:
+		return common.GitUntrackedStyle.Render("U")
+	case gitstatus.Ignored:
+		return common.GitIgnoredStyle.Render("I")
+	}
+	return common.FilePanelStyle.Render(" ")
+}
+
+// Branch of the work tree and its commits ahead and behind the upstream
+// branch, shown in the top line of the panel
+func gitBranchString(status *gitstatus.Status) string {
+	if status == nil || status.Branch == "" {
+		return ""
+	}
+	branch := icon.GitBranch + icon.Space + status.Branch
+	if status.Ahead > 0 {
+		branch += " ↑" + strconv.Itoa(status.Ahead)
+	}
+	if status.Behind > 0 {
+		branch += " ↓" + strconv.Itoa(status.Behind)
+	}
+	return branch
+}
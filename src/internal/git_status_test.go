@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/gitstatus"
+)
+
+// Run git in dir, failing the test on errors
+func testGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	args = append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	output, err := exec.Command("git", args...).CombinedOutput()
+	require.NoError(t, err, string(output))
+	return string(output)
+}
+
+// Work tree on the "work" branch, with a committed file and an untracked
+// file in a subdirectory
+func setupGitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	testGit(t, root, "init", "-q", "-b", "work")
+	require.NoError(t, os.WriteFile(filepath.Join(root, "committed.txt"), []byte("first\n"), 0644))
+	testGit(t, root, "add", ".")
+	testGit(t, root, "commit", "-q", "-m", "first")
+	require.NoError(t, os.Mkdir(filepath.Join(root, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", "new.txt"), nil, 0644))
+	return root
+}
+
+func TestModel_GitStatus(t *testing.T) {
+	root := setupGitRepo(t)
+	m := defaultModelConfig(false, true, []string{root})
+	m.gitStatuses = gitstatus.New(time.Hour, nil)
+	TeaUpdate(&m, tea.WindowSizeMsg{Width: 150, Height: 40})
+	m.getFilePanelItems()
+	require.Eventually(t, func() bool { return m.gitStatuses.Status(root) != nil }, 5*time.Second, 10*time.Millisecond)
+
+	render := m.filePanelRender()
+	assert.Contains(t, render, "work", "the branch is shown in the top line")
+	assert.Contains(t, render, "sub U", "directories show the state of their content")
+	assert.Contains(t, render, "committed.txt  ")
+
+	// Nothing is shown outside of work trees
+	m = defaultModelConfig(false, true, []string{t.TempDir()})
+	m.gitStatuses = gitstatus.New(time.Hour, nil)
+	TeaUpdate(&m, tea.WindowSizeMsg{Width: 150, Height: 40})
+	assert.NotContains(t, m.filePanelRender(), "work")
+}
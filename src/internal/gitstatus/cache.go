@@ -0,0 +1,110 @@
+package gitstatus
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Git status is not read for longer than this
+const readTimeout = 30 * time.Second
+
+// Statuses of work trees, read in the background. All methods are safe to
+// call on a nil *Cache, which reads nothing
+type Cache struct {
+	mu sync.Mutex
+	// Root of the work tree containing each directory, empty outside of work
+	// trees
+	roots    map[string]string
+	statuses map[string]*cachedStatus
+	// Statuses are read again when older
+	maxAge time.Duration
+	// Called when a status was read
+	notify func()
+}
+
+type cachedStatus struct {
+	status  *Status
+	readAt  time.Time
+	stale   bool
+	pending bool
+}
+
+// New returns a cache reading statuses again once they are older than
+// maxAge. notify is called from a background goroutine when a status was read
+func New(maxAge time.Duration, notify func()) *Cache {
+	return &Cache{
+		roots:    make(map[string]string),
+		statuses: make(map[string]*cachedStatus),
+		maxAge:   maxAge,
+		notify:   notify,
+	}
+}
+
+// Status returns the status of the work tree containing dir, nil outside of
+// work trees and until it is read. It is read again in the background when it
+// is too old or was invalidated
+func (c *Cache) Status(dir string) *Status {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	root, ok := c.roots[dir]
+	if !ok {
+		root = FindRoot(dir)
+		c.roots[dir] = root
+	}
+	if root == "" {
+		return nil
+	}
+	cached, ok := c.statuses[root]
+	if !ok {
+		cached = &cachedStatus{}
+		c.statuses[root] = cached
+	}
+	if !cached.pending && (cached.readAt.IsZero() || cached.stale || time.Since(cached.readAt) > c.maxAge) {
+		cached.pending = true
+		cached.stale = false
+		go c.read(root, cached)
+	}
+	return cached.status
+}
+
+// Invalidate the status of the work tree containing dir, after its content
+// changed
+func (c *Cache) Invalidate(dir string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// A work tree could have been created or removed
+	delete(c.roots, dir)
+	if cached, ok := c.statuses[FindRoot(dir)]; ok {
+		cached.stale = true
+	}
+}
+
+func (c *Cache) read(root string, cached *cachedStatus) {
+	ctx, cancel := context.WithTimeout(context.Background(), readTimeout)
+	defer cancel()
+	status, err := Read(ctx, root)
+	if err != nil {
+		slog.Debug("Error while reading git status", "root", root, "error", err)
+	}
+
+	c.mu.Lock()
+	cached.pending = false
+	cached.readAt = time.Now()
+	// The last status is kept when git fails, like while the index is locked
+	if err == nil {
+		cached.status = status
+	}
+	c.mu.Unlock()
+
+	if c.notify != nil {
+		c.notify()
+	}
+}
@@ -0,0 +1,160 @@
+package gitstatus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/bits"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// State of an item in a git work tree. Directories get the combined state of
+// their content
+type State uint8
+
+// States from the least to the most important
+const (
+	Ignored State = 1 << iota
+	Untracked
+	Staged
+	Modified
+	Conflicted
+)
+
+// Main returns the most important of the states
+func (s State) Main() State {
+	if s == 0 {
+		return 0
+	}
+	return 1 << (bits.Len8(uint8(s)) - 1)
+}
+
+// Status of a git work tree
+type Status struct {
+	Root   string
+	Branch string
+	// Commits ahead and behind the upstream branch
+	Ahead  int
+	Behind int
+	// States of the items listed by git status. The content of untracked and
+	// ignored directories isn't listed
+	items map[string]State
+	// Combined states of the directories containing listed items
+	dirs map[string]State
+}
+
+// State of the item at path, zero for unchanged items
+func (s *Status) State(path string) State {
+	if s == nil {
+		return 0
+	}
+	state := s.items[path] | s.dirs[path]
+	for dir := filepath.Dir(path); dir != s.Root && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		state |= s.items[dir] & (Untracked | Ignored)
+	}
+	return state
+}
+
+// FindRoot returns the root of the work tree containing dir, found by
+// looking for .git in its ancestors. It is empty outside of work trees
+func FindRoot(dir string) string {
+	for {
+		// .git is a file in linked work trees and submodules
+		if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Read the status of the work tree at root
+func Read(ctx context.Context, root string) (*Status, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", root, "status", "--porcelain=v2", "--branch",
+		"--ignored=matching", "-z")
+	// Reading the status must not lock the index while the user runs git
+	cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error running git status in %s: %w", root, err)
+	}
+	return parse(root, output), nil
+}
+
+// Parse the output of git status --porcelain=v2 --branch -z
+func parse(root string, output []byte) *Status {
+	s := &Status{
+		Root:  root,
+		items: make(map[string]State),
+		dirs:  make(map[string]State),
+	}
+	records := bytes.Split(output, []byte{0})
+	for i := 0; i < len(records); i++ {
+		record := string(records[i])
+		switch {
+		case strings.HasPrefix(record, "# branch.head "):
+			s.Branch = strings.TrimPrefix(record, "# branch.head ")
+		case strings.HasPrefix(record, "# branch.ab "):
+			var ahead, behind string
+			if _, err := fmt.Sscan(strings.TrimPrefix(record, "# branch.ab "), &ahead, &behind); err == nil {
+				s.Ahead, _ = strconv.Atoi(strings.TrimPrefix(ahead, "+"))
+				s.Behind, _ = strconv.Atoi(strings.TrimPrefix(behind, "-"))
+			}
+		case strings.HasPrefix(record, "1 "):
+			if fields := strings.SplitN(record, " ", 9); len(fields) == 9 {
+				s.add(fields[8], changeState(fields[1]))
+			}
+		case strings.HasPrefix(record, "2 "):
+			if fields := strings.SplitN(record, " ", 10); len(fields) == 10 {
+				s.add(fields[9], changeState(fields[1]))
+			}
+			// The original path of the rename comes next
+			i++
+		case strings.HasPrefix(record, "u "):
+			if fields := strings.SplitN(record, " ", 11); len(fields) == 11 {
+				s.add(fields[10], Conflicted)
+			}
+		case strings.HasPrefix(record, "? "):
+			s.add(record[2:], Untracked)
+		case strings.HasPrefix(record, "! "):
+			s.add(record[2:], Ignored)
+		}
+	}
+	return s
+}
+
+// State of a changed entry, from its XY field
+func changeState(xy string) State {
+	var state State
+	if len(xy) != 2 {
+		return state
+	}
+	if xy[0] != '.' {
+		state |= Staged
+	}
+	if xy[1] != '.' {
+		state |= Modified
+	}
+	return state
+}
+
+// Add the state of the item at relPath, a slash separated path relative to
+// the root. Directories end with a slash
+func (s *Status) add(relPath string, state State) {
+	path := filepath.Join(s.Root, filepath.FromSlash(strings.TrimSuffix(relPath, "/")))
+	s.items[path] |= state
+	// Directories containing ignored items are not ignored themselves
+	if state == Ignored {
+		return
+	}
+	for dir := filepath.Dir(path); dir != s.Root && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		s.dirs[dir] |= state
+	}
+}
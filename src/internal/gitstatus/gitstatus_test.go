@@ -0,0 +1,118 @@
+package gitstatus
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_Main(t *testing.T) {
+	assert.Equal(t, State(0), State(0).Main())
+	assert.Equal(t, Modified, (Staged | Modified).Main())
+	assert.Equal(t, Conflicted, (Untracked | Conflicted).Main())
+	assert.Equal(t, Ignored, Ignored.Main())
+}
+
+func Test_parse(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	output := strings.Join([]string{
+		"# branch.oid 0123456789abcdef",
+		"# branch.head main",
+		"# branch.upstream origin/main",
+		"# branch.ab +2 -1",
+		"1 .M N... 100644 100644 100644 aaa bbb src/main.go",
+		"1 M. N... 100644 100644 100644 aaa bbb src/with space.go",
+		"2 R. N... 100644 100644 100644 aaa bbb R100 docs/new.md",
+		"docs/old.md",
+		"u UU N... 100644 100644 100644 100644 aaa bbb ccc conflict.txt",
+		"? notes/",
+		"! build/",
+		"",
+	}, "\x00")
+	s := parse(root, []byte(output))
+
+	assert.Equal(t, "main", s.Branch)
+	assert.Equal(t, 2, s.Ahead)
+	assert.Equal(t, 1, s.Behind)
+	path := func(rel string) string { return filepath.Join(root, filepath.FromSlash(rel)) }
+	assert.Equal(t, Modified, s.State(path("src/main.go")))
+	assert.Equal(t, Staged, s.State(path("src/with space.go")))
+	assert.Equal(t, Staged|Modified, s.State(path("src")), "directories combine the states of their content")
+	assert.Equal(t, Staged, s.State(path("docs/new.md")))
+	assert.Zero(t, s.State(path("docs/old.md")))
+	assert.Equal(t, Conflicted, s.State(path("conflict.txt")))
+	assert.Equal(t, Untracked, s.State(path("notes")))
+	assert.Equal(t, Untracked, s.State(path("notes/deep/file.txt")), "untracked directories are not listed")
+	assert.Equal(t, Ignored, s.State(path("build/out.o")))
+	assert.Zero(t, s.State(path("README.md")))
+	assert.Zero(t, (*Status)(nil).State(path("src")))
+}
+
+// Work tree with a committed file, a modified file and an untracked file
+func setupRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", root, "-c", "user.name=test", "-c", "user.email=test@example.com"},
+			args...)...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	git("init", "-q", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(root, "committed.txt"), []byte("a"), 0644))
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+	require.NoError(t, os.WriteFile(filepath.Join(root, "committed.txt"), []byte("b"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(root, "new"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "new", "file.txt"), nil, 0644))
+	return root
+}
+
+func TestRead(t *testing.T) {
+	root := setupRepo(t)
+	assert.Equal(t, root, FindRoot(filepath.Join(root, "new")))
+	assert.Empty(t, FindRoot(t.TempDir()))
+
+	s, err := Read(context.Background(), root)
+	require.NoError(t, err)
+	assert.Equal(t, "main", s.Branch)
+	assert.Equal(t, Modified, s.State(filepath.Join(root, "committed.txt")))
+	assert.Equal(t, Untracked, s.State(filepath.Join(root, "new", "file.txt")))
+
+	_, err = Read(context.Background(), t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestCache(t *testing.T) {
+	root := setupRepo(t)
+	var notified atomic.Int32
+	c := New(time.Hour, func() { notified.Add(1) })
+
+	assert.Nil(t, c.Status(root), "statuses are read in the background")
+	require.Eventually(t, func() bool { return notified.Load() == 1 }, 5*time.Second, 5*time.Millisecond)
+	s := c.Status(filepath.Join(root, "new"))
+	require.NotNil(t, s)
+	assert.Same(t, s, c.Status(root), "statuses are shared by the directories of a work tree")
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "other.txt"), nil, 0644))
+	c.Invalidate(root)
+	assert.Same(t, s, c.Status(root), "the last status is shown until it is read again")
+	require.Eventually(t, func() bool { return notified.Load() == 2 }, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, Untracked, c.Status(root).State(filepath.Join(root, "other.txt")))
+
+	assert.Nil(t, c.Status(t.TempDir()))
+	var nilCache *Cache
+	assert.Nil(t, nilCache.Status(root))
+	nilCache.Invalidate(root)
+}
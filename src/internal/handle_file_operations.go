@@ -8,6 +8,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,7 +45,7 @@ func (m *model) IsRenamingConflicting() bool {
 	}
 
 	oldPath := panel.element[panel.cursor].location
-	newPath := filepath.Join(panel.location, panel.rename.Value())
+	newPath := filepath.Join(filepath.Dir(oldPath), panel.rename.Value())
 
 	if oldPath == newPath {
 		return false
@@ -76,8 +78,10 @@ func (m *model) panelItemRename() {
 		return
 	}
 
-	cursorPos := strings.LastIndex(panel.element[panel.cursor].name, ".")
-	nameLen := len(panel.element[panel.cursor].name)
+	// The shown name can be a relative path, like in flatten mode
+	name := filepath.Base(panel.element[panel.cursor].location)
+	cursorPos := strings.LastIndex(name, ".")
+	nameLen := len(name)
 	if cursorPos == -1 || cursorPos == 0 && nameLen > 0 || panel.element[panel.cursor].directory {
 		cursorPos = nameLen
 	}
@@ -85,7 +89,7 @@ func (m *model) panelItemRename() {
 	m.fileModel.renaming = true
 	panel.renaming = true
 	m.firstTextInput = true
-	panel.rename = common.GenerateRenameTextInput(m.fileModel.width-4, cursorPos, panel.element[panel.cursor].name)
+	panel.rename = common.GenerateRenameTextInput(m.fileModel.width-4, cursorPos, name)
 }
 
 func (m *model) deleteItemWarn() {
@@ -580,8 +584,12 @@ func (m *model) openFileWithEditor() tea.Cmd {
 	parts := strings.Fields(editor)
 	cmd := parts[0]
 
-	//nolint:gocritic // appendAssign: intentionally creating a new slice
-	args := append(parts[1:], panel.element[panel.cursor].location)
+	args := slices.Clone(parts[1:])
+	// Most editors accept +LINE to open the file at a line
+	if line := panel.element[panel.cursor].line; line > 0 {
+		args = append(args, "+"+strconv.Itoa(line))
+	}
+	args = append(args, panel.element[panel.cursor].location)
 
 	c := exec.Command(cmd, args...)
 
@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+// Number of tabs in the panel. A panel always has at least one tab
+func (panel *filePanel) tabCount() int {
+	return max(len(panel.tabs), 1)
+}
+
+// Snapshot of the state of the active tab
+func (panel *filePanel) currentTab() filePanelTab {
+	return filePanelTab{
+		location:         panel.location,
+		cursor:           panel.cursor,
+		render:           panel.render,
+		sortOptions:      panel.sortOptions.data,
+		panelMode:        panel.panelMode,
+		selected:         panel.selected,
+		directoryRecords: panel.directoryRecords,
+		searchBarValue:   panel.searchBar.Value(),
+		searchMode:       panel.searchMode,
+		searchPinned:     panel.searchPinned,
+		history:          panel.history,
+	}
+}
+
+// Save the state of the active tab into panel.tabs
+func (panel *filePanel) saveActiveTab() {
+	if len(panel.tabs) == 0 {
+		panel.tabs = []filePanelTab{panel.currentTab()}
+		panel.activeTab = 0
+		return
+	}
+	panel.tabs[panel.activeTab] = panel.currentTab()
+}
+
+// Restore state of the tab at index and make it the active tab
+func (panel *filePanel) loadTab(index int) {
+	panel.closeFinder()
+	panel.stopRangeSelect()
+	tab := panel.tabs[index]
+	panel.activeTab = index
+	panel.location = tab.location
+	panel.cursor = tab.cursor
+	panel.render = tab.render
+	panel.sortOptions.data = tab.sortOptions
+	panel.sortOptions.cursor = tab.sortOptions.selected
+	panel.panelMode = tab.panelMode
+	panel.selected = tab.selected
+	panel.directoryRecords = tab.directoryRecords
+	panel.searchBar.SetValue(tab.searchBarValue)
+	panel.searchMode = tab.searchMode
+	panel.searchPinned = tab.searchPinned
+	panel.history = tab.history
+	panel.resetElements()
+}
+
+// Open a new tab at the panel's current location and switch to it
+func (panel *filePanel) openNewTab() {
+	panel.saveActiveTab()
+	newTab := filePanelTab{
+		location:         panel.location,
+		sortOptions:      panel.sortOptions.data,
+		panelMode:        browserMode,
+		selected:         []string{},
+		directoryRecords: make(map[string]directoryRecord),
+	}
+	panel.tabs = slices.Insert(panel.tabs, panel.activeTab+1, newTab)
+	panel.loadTab(panel.activeTab + 1)
+}
+
+// Close the active tab. The last remaining tab cannot be closed
+func (panel *filePanel) closeTab() {
+	if panel.tabCount() <= 1 {
+		return
+	}
+	panel.tabs = slices.Delete(panel.tabs, panel.activeTab, panel.activeTab+1)
+	panel.loadTab(min(panel.activeTab, len(panel.tabs)-1))
+	if len(panel.tabs) == 1 {
+		panel.tabs = nil
+		panel.activeTab = 0
+	}
+}
+
+// Switch to the tab that is offset positions away from the active tab,
+// wrapping around at both ends
+func (panel *filePanel) switchTab(offset int) {
+	count := panel.tabCount()
+	if count <= 1 {
+		return
+	}
+	panel.saveActiveTab()
+	panel.loadTab(((panel.activeTab+offset)%count + count) % count)
+}
+
+// Titles of the tabs to render in the panel's top border. Returns nil when
+// the panel has a single tab
+func (panel *filePanel) tabTitles() []string {
+	if panel.tabCount() <= 1 {
+		return nil
+	}
+	titles := make([]string, len(panel.tabs))
+	for i, tab := range panel.tabs {
+		location := tab.location
+		if i == panel.activeTab {
+			location = panel.location
+		}
+		titles[i] = strconv.Itoa(i+1) + ":" + filepath.Base(location)
+	}
+	return titles
+}
+
+// Top border of the file panel containing its tab strip. Empty string means
+// the default border should be used
+func (panel *filePanel) tabBorder(width int) string {
+	titles := panel.tabTitles()
+	if titles == nil {
+		return ""
+	}
+	return common.GenerateTabBorder(titles, panel.activeTab, width)
+}
+
+func (m *model) openNewTab() {
+	m.fileModel.filePanels[m.filePanelFocusIndex].openNewTab()
+}
+
+func (m *model) closeTab() {
+	m.fileModel.filePanels[m.filePanelFocusIndex].closeTab()
+	m.fileMetaData.renderIndex = 0
+}
+
+func (m *model) nextTab() {
+	m.fileModel.filePanels[m.filePanelFocusIndex].switchTab(1)
+	m.fileMetaData.renderIndex = 0
+}
+
+func (m *model) previousTab() {
+	m.fileModel.filePanels[m.filePanelFocusIndex].switchTab(-1)
+	m.fileMetaData.renderIndex = 0
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_filePanelTabs(t *testing.T) {
+	panel := defaultFilePanel("/a")
+	panel.cursor = 3
+	panel.selected = []string{"/a/x"}
+	assert.Equal(t, 1, panel.tabCount())
+	assert.Nil(t, panel.tabTitles())
+
+	// New tab opens at the same location with fresh state
+	panel.openNewTab()
+	require.Equal(t, 2, panel.tabCount())
+	assert.Equal(t, 1, panel.activeTab)
+	assert.Equal(t, "/a", panel.location)
+	assert.Equal(t, 0, panel.cursor)
+	assert.Empty(t, panel.selected)
+
+	panel.location = "/b"
+	panel.cursor = 1
+	panel.sortOptions.data.reversed = !panel.sortOptions.data.reversed
+	assert.Equal(t, []string{"1:a", "2:b"}, panel.tabTitles())
+
+	// Switching restores the state of each tab
+	panel.switchTab(1)
+	assert.Equal(t, 0, panel.activeTab)
+	assert.Equal(t, "/a", panel.location)
+	assert.Equal(t, 3, panel.cursor)
+	assert.Equal(t, []string{"/a/x"}, panel.selected)
+
+	panel.switchTab(-1)
+	assert.Equal(t, 1, panel.activeTab)
+	assert.Equal(t, "/b", panel.location)
+	assert.Equal(t, 1, panel.cursor)
+	assert.NotEqual(t, panel.tabs[0].sortOptions.reversed, panel.sortOptions.data.reversed)
+
+	// Closing the last but one tab goes back to a single tab panel
+	panel.closeTab()
+	assert.Equal(t, 1, panel.tabCount())
+	assert.Nil(t, panel.tabs)
+	assert.Equal(t, "/a", panel.location)
+	assert.Equal(t, 3, panel.cursor)
+
+	panel.closeTab()
+	assert.Equal(t, "/a", panel.location)
+}
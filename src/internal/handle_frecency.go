@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	variable "github.com/yorukot/superfile/src/config"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/frecency"
+)
+
+// Load the frecency database, seeding it from zoxide if configured
+func loadFrecencyDatabase() *frecency.Database {
+	db := frecency.Load(variable.FrecencyFile)
+	if common.Config.FrecencyImportZoxide && db.Len() == 0 {
+		zoxideFile := frecency.ZoxideDatabaseFile()
+		if err := db.ImportZoxide(zoxideFile); err != nil {
+			slog.Error("Error while importing zoxide database", "file", zoxideFile, "error", err)
+		}
+	}
+	return db
+}
+
+// Must be called after every location change of a file panel. The panel is
+// sorted with the settings saved for its new location
+func (m *model) handleLocationChange(panel *filePanel) {
+	m.applyDirectorySort(panel)
+	m.pruneCheckedTags()
+	m.frecency.Add(panel.location)
+}
+
+func (m *model) openJumpModal() {
+	m.jumpModal.Open(m.frecency.Ranked(), 0)
+	m.firstTextInput = true
+}
+
+func (m *model) jumpModalKey(msg string) {
+	dirs := m.jumpModal.Items()
+	index, ok := m.jumpModal.HandleKey(msg)
+	if !ok {
+		return
+	}
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.changeLocation(dirs[index])
+	m.handleLocationChange(panel)
+}
+
+// Change directory of the focused panel for the prompt cd command. A single
+// argument that is not an accessible directory, as well as multiple arguments,
+// are used as keywords to find the best ranked matching directory
+func (m *model) cdCurrentFilePanel(location string, keywords []string) error {
+	if len(keywords) == 0 {
+		err := m.updateCurrentFilePanelDir(location)
+		if err == nil {
+			return nil
+		}
+		dir, found := m.frecency.Query([]string{location})
+		if !found {
+			return err
+		}
+		return m.updateCurrentFilePanelDir(dir)
+	}
+	dir, found := m.frecency.Query(keywords)
+	if !found {
+		return errors.New("no ranked directory matches " + strings.Join(keywords, " "))
+	}
+	return m.updateCurrentFilePanelDir(dir)
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/frecency"
+)
+
+func TestModel_FrecencyJump(t *testing.T) {
+	dir := t.TempDir()
+	api := filepath.Join(dir, "projects", "api")
+	docs := filepath.Join(dir, "projects", "docs")
+	require.NoError(t, os.MkdirAll(api, 0755))
+	require.NoError(t, os.MkdirAll(docs, 0755))
+
+	m := defaultModelConfig(false, true, []string{dir})
+	m.frecency = frecency.New(filepath.Join(dir, "frecency.json"))
+	panel := &m.fileModel.filePanels[0]
+
+	require.NoError(t, m.updateCurrentFilePanelDir(docs))
+	require.NoError(t, m.updateCurrentFilePanelDir(api))
+	require.NoError(t, m.updateCurrentFilePanelDir(dir))
+	require.NoError(t, m.updateCurrentFilePanelDir(api))
+
+	t.Run("cd with keywords", func(t *testing.T) {
+		require.NoError(t, m.cdCurrentFilePanel("", []string{"proj", "doc"}))
+		assert.Equal(t, docs, panel.location)
+		assert.Error(t, m.cdCurrentFilePanel("", []string{"nothing"}))
+	})
+
+	t.Run("cd falls back to keyword for non existent path", func(t *testing.T) {
+		require.NoError(t, m.cdCurrentFilePanel("api", nil))
+		assert.Equal(t, api, panel.location)
+		assert.Error(t, m.cdCurrentFilePanel("nothing", nil))
+	})
+
+	t.Run("Jump modal lists most frecent first", func(t *testing.T) {
+		m.openJumpModal()
+		require.True(t, m.jumpModal.IsOpen())
+		assert.Equal(t, api, m.jumpModal.Items()[0])
+		m.jumpModalKey(common.Hotkeys.ConfirmTyping[0])
+		assert.False(t, m.jumpModal.IsOpen())
+		assert.Equal(t, api, panel.location)
+	})
+}
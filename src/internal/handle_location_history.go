@@ -0,0 +1,93 @@
+package internal
+
+// Maximum number of locations remembered in the history of a file panel
+const maxHistoryLength = 100
+
+// Record a move from location from to location to. Locations after the
+// current one are dropped, as in a web browser
+func (h *locationHistory) visit(from string, to string) {
+	if from == to {
+		return
+	}
+	if len(h.locations) == 0 {
+		h.locations = []string{from}
+		h.index = 0
+	}
+	h.locations = append(h.locations[:h.index+1], to)
+	if len(h.locations) > maxHistoryLength {
+		h.locations = h.locations[len(h.locations)-maxHistoryLength:]
+	}
+	h.index = len(h.locations) - 1
+}
+
+func (h *locationHistory) back() (string, bool) {
+	if h.index <= 0 || h.index >= len(h.locations) {
+		return "", false
+	}
+	h.index--
+	return h.locations[h.index], true
+}
+
+func (h *locationHistory) forward() (string, bool) {
+	if h.index >= len(h.locations)-1 {
+		return "", false
+	}
+	h.index++
+	return h.locations[h.index], true
+}
+
+// Locations of the history ordered from the most recent, and the position
+// of the current location in that order
+func (h *locationHistory) recentLocations(currentLocation string) ([]string, int) {
+	if len(h.locations) == 0 {
+		return []string{currentLocation}, 0
+	}
+	recent := make([]string, len(h.locations))
+	for i, location := range h.locations {
+		recent[len(h.locations)-1-i] = location
+	}
+	return recent, len(h.locations) - 1 - h.index
+}
+
+// Move to the location at position recentIndex of recentLocations()
+func (h *locationHistory) jumpTo(recentIndex int) (string, bool) {
+	index := len(h.locations) - 1 - recentIndex
+	if index < 0 || index >= len(h.locations) {
+		return "", false
+	}
+	h.index = index
+	return h.locations[index], true
+}
+
+func (m *model) historyBack() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	if location, ok := panel.history.back(); ok {
+		panel.setLocation(location)
+		m.handleLocationChange(panel)
+	}
+}
+
+func (m *model) historyForward() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	if location, ok := panel.history.forward(); ok {
+		panel.setLocation(location)
+		m.handleLocationChange(panel)
+	}
+}
+
+func (m *model) openHistoryModal() {
+	panel := m.fileModel.filePanels[m.filePanelFocusIndex]
+	m.historyModal.Open(panel.history.recentLocations(panel.location))
+}
+
+func (m *model) historyModalKey(msg string) {
+	recentIndex, ok := m.historyModal.HandleKey(msg)
+	if !ok {
+		return
+	}
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	if location, ok := panel.history.jumpTo(recentIndex); ok {
+		panel.setLocation(location)
+		m.handleLocationChange(panel)
+	}
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func Test_locationHistory(t *testing.T) {
+	var h locationHistory
+	_, ok := h.back()
+	assert.False(t, ok)
+
+	h.visit("/a", "/b")
+	h.visit("/b", "/c")
+	h.visit("/c", "/c")
+	assert.Equal(t, []string{"/a", "/b", "/c"}, h.locations)
+
+	location, ok := h.back()
+	assert.True(t, ok)
+	assert.Equal(t, "/b", location)
+	location, _ = h.back()
+	assert.Equal(t, "/a", location)
+	_, ok = h.back()
+	assert.False(t, ok)
+
+	location, ok = h.forward()
+	assert.True(t, ok)
+	assert.Equal(t, "/b", location)
+
+	recent, cursor := h.recentLocations("/b")
+	assert.Equal(t, []string{"/c", "/b", "/a"}, recent)
+	assert.Equal(t, 1, cursor)
+
+	// Visiting a new location drops the forward history
+	h.visit("/b", "/d")
+	assert.Equal(t, []string{"/a", "/b", "/d"}, h.locations)
+	_, ok = h.forward()
+	assert.False(t, ok)
+
+	location, ok = h.jumpTo(2)
+	assert.True(t, ok)
+	assert.Equal(t, "/a", location)
+	assert.Equal(t, 0, h.index)
+
+	for range maxHistoryLength {
+		h.visit("/x", "/y")
+		h.visit("/y", "/x")
+	}
+	assert.Len(t, h.locations, maxHistoryLength)
+	assert.Equal(t, maxHistoryLength-1, h.index)
+}
+
+func Test_locationHistoryEmpty(t *testing.T) {
+	var h locationHistory
+	recent, cursor := h.recentLocations("/a")
+	assert.Equal(t, []string{"/a"}, recent)
+	assert.Equal(t, 0, cursor)
+	_, ok := h.jumpTo(0)
+	assert.False(t, ok)
+}
+
+func TestModel_LocationHistory(t *testing.T) {
+	m := defaultModelConfig(false, true, []string{"/a"})
+	panel := &m.fileModel.filePanels[0]
+	panel.changeLocation("/a/b")
+	panel.cursor = 4
+	assert.NoError(t, m.updateCurrentFilePanelDir("/"))
+
+	m.historyBack()
+	assert.Equal(t, "/a/b", panel.location)
+	assert.Equal(t, 4, panel.cursor, "cursor position should be restored")
+	m.historyBack()
+	assert.Equal(t, "/a", panel.location)
+	m.historyForward()
+	m.historyForward()
+	assert.Equal(t, "/", panel.location)
+
+	m.openHistoryModal()
+	assert.True(t, m.historyModal.IsOpen())
+	m.historyModalKey(common.Hotkeys.ListDown[0])
+	m.historyModalKey(common.Hotkeys.ListDown[0])
+	m.historyModalKey(common.Hotkeys.Confirm[0])
+	assert.False(t, m.historyModal.IsOpen())
+	assert.Equal(t, "/a", panel.location)
+	m.historyForward()
+	assert.Equal(t, "/a/b", panel.location)
+}
@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"log/slog"
+	"os"
+	"slices"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/marks"
+)
+
+// Handle the key typed after the set mark or jump to mark hotkey. Any key
+// that is not a valid mark label cancels the pending action
+func (m *model) pendingMarkKey(msg string) {
+	action := m.pendingMark
+	m.pendingMark = noPendingMark
+	if !marks.IsValidLabel(msg) {
+		return
+	}
+	switch action {
+	case setMarkPending:
+		m.setMark(msg)
+	case jumpToMarkPending:
+		m.jumpToMark(msg)
+	case noPendingMark:
+	}
+}
+
+// Mark the file under the cursor of the focused panel, or its location if the
+// cursor is on a directory or the panel is empty
+func (m *model) setMark(label string) {
+	panel := m.fileModel.filePanels[m.filePanelFocusIndex]
+	location := panel.location
+	if len(panel.element) > 0 && !panel.element[panel.cursor].directory {
+		location = panel.element[panel.cursor].location
+	}
+	if err := m.marks.Set(label, location); err != nil {
+		slog.Error("Error while setting mark", "label", label, "error", err)
+	}
+}
+
+func (m *model) jumpToMark(label string) {
+	mark, ok := m.marks.Get(label)
+	if !ok {
+		slog.Debug("No mark to jump to", "label", label)
+		return
+	}
+	m.openMarkLocation(mark.Location)
+}
+
+// Open a marked directory, or the directory of a marked file with the cursor on it
+func (m *model) openMarkLocation(location string) {
+	info, err := os.Stat(location)
+	if err != nil {
+		slog.Error("Marked location is not accessible", "location", location, "error", err)
+		return
+	}
+	if !info.IsDir() {
+		m.openItemLocation(location)
+		return
+	}
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.changeLocation(location)
+	m.handleLocationChange(panel)
+}
+
+func (m *model) marksModalItems() []string {
+	items := make([]string, len(m.marksModal.labels))
+	for i, label := range m.marksModal.labels {
+		mark, _ := m.marks.Get(label)
+		items[i] = label + "  " + mark.Name + "  (" + mark.Location + ")"
+	}
+	return items
+}
+
+// Open the marks list
+func (m *model) openMarksModal() {
+	m.marksModal.labels = m.marks.Labels()
+	m.marksModal.picker.Open(m.marksModalItems(), 0)
+}
+
+// Reload the marks list after a change, keeping the cursor near cursor
+func (m *model) refreshMarksModal(cursor int) {
+	m.marksModal.labels = m.marks.Labels()
+	m.marksModal.picker.Open(m.marksModalItems(), min(cursor, len(m.marksModal.labels)-1))
+}
+
+func (m *model) marksModalKey(msg string) {
+	labels := m.marksModal.labels
+	switch {
+	case slices.Contains(common.Hotkeys.DeleteItems, msg):
+		index, ok := m.marksModal.picker.Selected()
+		if !ok {
+			return
+		}
+		if err := m.marks.Delete(labels[index]); err != nil {
+			slog.Error("Error while deleting mark", "label", labels[index], "error", err)
+		}
+		m.refreshMarksModal(index)
+	case slices.Contains(common.Hotkeys.FilePanelItemRename, msg):
+		index, ok := m.marksModal.picker.Selected()
+		if !ok {
+			return
+		}
+		mark, _ := m.marks.Get(labels[index])
+		m.marksModal.renaming = labels[index]
+		m.marksModal.textInput = common.GenerateRenameTextInput(common.ModalWidth-4, len(mark.Name), mark.Name)
+		m.firstTextInput = true
+	default:
+		index, ok := m.marksModal.picker.HandleKey(msg)
+		if !ok {
+			return
+		}
+		mark, _ := m.marks.Get(labels[index])
+		m.openMarkLocation(mark.Location)
+	}
+}
+
+func (m *model) marksRenameKey(msg string) {
+	switch {
+	case slices.Contains(common.Hotkeys.CancelTyping, msg):
+		m.marksModal.renaming = ""
+	case slices.Contains(common.Hotkeys.ConfirmTyping, msg):
+		label := m.marksModal.renaming
+		m.marksModal.renaming = ""
+		if err := m.marks.Rename(label, m.marksModal.textInput.Value()); err != nil {
+			slog.Error("Error while renaming mark", "label", label, "error", err)
+		}
+		m.refreshMarksModal(slices.Index(m.marksModal.labels, label))
+	}
+}
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/marks"
+)
+
+func TestModel_Marks(t *testing.T) {
+	dir := t.TempDir()
+	subDir := filepath.Join(dir, "sub")
+	file := filepath.Join(subDir, "b.txt")
+	require.NoError(t, os.Mkdir(subDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(subDir, "a.txt"), nil, 0644))
+	require.NoError(t, os.WriteFile(file, nil, 0644))
+
+	m := defaultModelConfig(false, true, []string{dir})
+	m.marks = marks.New(filepath.Join(dir, "marks.json"))
+	panel := &m.fileModel.filePanels[0]
+	panel.element = returnDirElement(panel.location, false, panel.sortOptions.data, nil)
+
+	typeKeys := func(keys ...string) {
+		for _, key := range keys {
+			if m.pendingMark != noPendingMark {
+				m.pendingMarkKey(key)
+			} else {
+				m.mainKey(key, nil)
+			}
+		}
+	}
+
+	// Cursor on a directory marks the panel location
+	typeKeys(common.Hotkeys.SetMark[0], "a")
+	require.NoError(t, m.updateCurrentFilePanelDir(subDir))
+	panel.element = returnDirElement(panel.location, false, panel.sortOptions.data, nil)
+	panel.cursor = 1
+	// Cursor on a file marks the file
+	typeKeys(common.Hotkeys.SetMark[0], "b")
+	// Invalid label cancels
+	typeKeys(common.Hotkeys.SetMark[0], "1")
+	assert.Equal(t, noPendingMark, m.pendingMark)
+	assert.Equal(t, []string{"a", "b"}, m.marks.Labels())
+
+	t.Run("Jump to directory and file marks", func(t *testing.T) {
+		typeKeys(common.Hotkeys.JumpToMark[0], "a")
+		assert.Equal(t, dir, panel.location)
+
+		panel.cursor = 0
+		typeKeys(common.Hotkeys.JumpToMark[0], "b")
+		assert.Equal(t, subDir, panel.location)
+		assert.Equal(t, file, panel.element[panel.cursor].location)
+
+		typeKeys(common.Hotkeys.JumpToMark[0], "c")
+		assert.Equal(t, subDir, panel.location)
+	})
+
+	t.Run("Marks modal rename, delete and jump", func(t *testing.T) {
+		m.openMarksModal()
+		require.True(t, m.marksModal.picker.IsOpen())
+		assert.Len(t, m.marksModal.picker.Items(), 2)
+
+		m.marksModalKey(common.Hotkeys.FilePanelItemRename[0])
+		assert.Equal(t, "a", m.marksModal.renaming)
+		m.marksModal.textInput.SetValue("root")
+		m.marksRenameKey(common.Hotkeys.ConfirmTyping[0])
+		mark, _ := m.marks.Get("a")
+		assert.Equal(t, "root", mark.Name)
+
+		m.marksModalKey(common.Hotkeys.ListDown[0])
+		m.marksModalKey(common.Hotkeys.DeleteItems[0])
+		assert.Equal(t, []string{"a"}, m.marks.Labels())
+		assert.True(t, m.marksModal.picker.IsOpen())
+
+		m.marksModalKey(common.Hotkeys.Confirm[0])
+		assert.False(t, m.marksModal.picker.IsOpen())
+		assert.Equal(t, dir, panel.location)
+	})
+}
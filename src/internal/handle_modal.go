@@ -68,13 +68,15 @@ func (m *model) confirmRename() {
 	}
 
 	oldPath := panel.element[panel.cursor].location
-	newPath := filepath.Join(panel.location, panel.rename.Value())
+	newPath := filepath.Join(filepath.Dir(oldPath), panel.rename.Value())
 
 	// Rename the file
 	err := os.Rename(oldPath, newPath)
 	if err != nil {
 		slog.Error("Error while confirmRename during rename", "error", err)
 		// Dont return. We have to also reset the panel and model information
+	} else if err = m.fileTags.Move(oldPath, newPath); err != nil {
+		slog.Error("Error while moving tags of renamed item", "error", err)
 	}
 	m.fileModel.renaming = false
 	panel.rename.Blur()
@@ -96,6 +98,7 @@ func (m *model) confirmSortOptions() {
 	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
 	panel.sortOptions.data.selected = panel.sortOptions.cursor
 	panel.sortOptions.open = false
+	m.saveDirectorySort(panel)
 }
 
 // Move the cursor up in the sort options menu
@@ -121,6 +124,12 @@ func (m *model) sortOptionsListDown() {
 func (m *model) toggleReverseSort() {
 	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
 	panel.sortOptions.data.reversed = !panel.sortOptions.data.reversed
+	m.saveDirectorySort(panel)
+}
+
+func (m *model) toggleDirectoriesFirst() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.sortOptions.data.directoriesFirst = !panel.sortOptions.data.directoriesFirst
 }
 
 // Cancel search, this will clear all searchbar input
@@ -128,6 +137,7 @@ func (m *model) cancelSearch() {
 	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
 	panel.searchBar.Blur()
 	panel.searchBar.SetValue("")
+	panel.searchPinned = false
 }
 
 // Confirm search. This will exit the search bar and filter the files
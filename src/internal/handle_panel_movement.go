@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/yorukot/superfile/src/internal/utils"
 
@@ -16,6 +17,7 @@ import (
 func (m *model) changeFilePanelMode() {
 	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
 	if panel.panelMode == selectMode {
+		panel.stopRangeSelect()
 		panel.selected = panel.selected[:0]
 		panel.panelMode = browserMode
 	} else if panel.panelMode == browserMode {
@@ -26,13 +28,60 @@ func (m *model) changeFilePanelMode() {
 // Back to parent directory
 func (m *model) parentDirectory() {
 	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	// Leave the find results first
+	if panel.finder.active {
+		panel.closeFinder()
+		return
+	}
+	if panel.showsTree() && m.collapseTreeNode() {
+		return
+	}
+	previousLocation := panel.location
+	panel.changeLocation(filepath.Dir(panel.location))
+	m.handleLocationChange(panel)
+	// The cursor stays on the directory that was left, as in the parent column
+	if m.fileModel.millerColumns {
+		m.reloadPanelElements(panel, previousLocation)
+	}
+}
+
+// Open the directory containing itemPath in the focused panel, with the cursor
+// on itemPath
+func (m *model) openItemLocation(itemPath string) {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.changeLocation(filepath.Dir(itemPath))
+	m.handleLocationChange(panel)
+	panel.searchBar.SetValue("")
+	panel.searchPinned = false
+	panel.element = m.dirElements(panel)
+	panel.lastTimeGetElement = time.Now()
+	for i, item := range panel.element {
+		if item.location == itemPath {
+			panel.cursor = i
+			panel.render = max(0, i-panelElementHeight(m.mainPanelHeight)+1)
+			break
+		}
+	}
+}
+
+// Change location of the panel and add the new location to the panel history
+func (panel *filePanel) changeLocation(location string) {
+	panel.history.visit(panel.location, location)
+	panel.setLocation(location)
+}
+
+// Set location of the panel without touching its history. Cursor position
+// in the current location is saved and the one of the new location restored
+func (panel *filePanel) setLocation(location string) {
+	panel.closeFinder()
+	if panel.directoryRecords == nil {
+		panel.directoryRecords = make(map[string]directoryRecord)
+	}
 	panel.directoryRecords[panel.location] = directoryRecord{
 		directoryCursor: panel.cursor,
 		directoryRender: panel.render,
 	}
-	fullPath := panel.location
-	parentDir := filepath.Dir(fullPath)
-	panel.location = parentDir
+	panel.location = location
 	curDirectoryRecord, hasRecord := panel.directoryRecords[panel.location]
 	if hasRecord {
 		panel.cursor = curDirectoryRecord.directoryCursor
@@ -51,21 +100,17 @@ func (m *model) enterPanel() {
 		return
 	}
 
+	if panel.finder.active {
+		m.openItemLocation(panel.element[panel.cursor].location)
+		return
+	}
+
 	if panel.element[panel.cursor].directory {
-		panel.directoryRecords[panel.location] = directoryRecord{
-			directoryCursor: panel.cursor,
-			directoryRender: panel.render,
-		}
-		panel.location = panel.element[panel.cursor].location
-		curDirectoryRecord, hasRecord := panel.directoryRecords[panel.location]
-		if hasRecord {
-			panel.cursor = curDirectoryRecord.directoryCursor
-			panel.render = curDirectoryRecord.directoryRender
-		} else {
-			panel.cursor = 0
-			panel.render = 0
+		panel.changeLocation(panel.element[panel.cursor].location)
+		m.handleLocationChange(panel)
+		if !panel.searchPinned {
+			panel.searchBar.SetValue("")
 		}
-		panel.searchBar.SetValue("")
 	} else if !panel.element[panel.cursor].directory {
 		fileInfo, err := os.Lstat(panel.element[panel.cursor].location)
 		if err != nil {
@@ -86,7 +131,8 @@ func (m *model) enterPanel() {
 			}
 
 			if targetInfo.IsDir() {
-				m.fileModel.filePanels[m.filePanelFocusIndex].location = targetPath
+				panel.changeLocation(targetPath)
+				m.handleLocationChange(panel)
 			}
 
 			return
@@ -125,34 +171,36 @@ func (m *model) sidebarSelectDirectory() {
 	}
 	m.focusPanel = nonePanelFocus
 	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.focusType = focus
 
-	panel.directoryRecords[panel.location] = directoryRecord{
-		directoryCursor: panel.cursor,
-		directoryRender: panel.render,
-	}
-
-	panel.location = m.sidebarModel.GetCurrentDirectoryLocation()
-	curDirectoryRecord, hasRecord := panel.directoryRecords[panel.location]
-	if hasRecord {
-		panel.cursor = curDirectoryRecord.directoryCursor
-		panel.render = curDirectoryRecord.directoryRender
-	} else {
-		panel.cursor = 0
-		panel.render = 0
+	// Tagged files are opened in their directory
+	location := m.sidebarModel.GetCurrentDirectoryLocation()
+	if info, err := os.Stat(location); err == nil && !info.IsDir() {
+		m.openItemLocation(location)
+		return
 	}
-	panel.focusType = focus
+	panel.changeLocation(location)
+	m.handleLocationChange(panel)
 }
 
 // Select all item in the file panel (only work on select mode)
 func (m *model) selectAllItem() {
 	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.stopRangeSelect()
+	selected := make(map[string]bool, len(panel.selected))
+	for _, location := range panel.selected {
+		selected[location] = true
+	}
 	for _, item := range panel.element {
-		panel.selected = append(panel.selected, item.location)
+		if !selected[item.location] {
+			panel.selected = append(panel.selected, item.location)
+		}
 	}
 }
 
 // Select the item where cursor located (only work on select mode)
 func (panel *filePanel) singleItemSelect() {
+	panel.stopRangeSelect()
 	if len(panel.element) > 0 && panel.cursor >= 0 && panel.cursor < len(panel.element) {
 		elementLocation := panel.element[panel.cursor].location
 
@@ -192,6 +240,10 @@ func (m *model) toggleFooterController() {
 // Focus on search bar
 func (m *model) searchBarFocus() {
 	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	if panel.finder.active {
+		m.openFindBar(panel.finder.content)
+		return
+	}
 	if panel.searchBar.Focused() {
 		panel.searchBar.Blur()
 	} else {
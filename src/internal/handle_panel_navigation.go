@@ -55,16 +55,12 @@ func (m *model) createNewFilePanel(location string) error {
 		}
 	}
 
+	m.handleLocationChange(&m.fileModel.filePanels[len(m.fileModel.filePanels)-1])
+
 	m.fileModel.filePanels[m.filePanelFocusIndex].focusType = noneFocus
 	m.fileModel.filePanels[m.filePanelFocusIndex+1].focusType = returnFocusType(m.focusPanel)
-	m.fileModel.width = (m.fullWidth - common.Config.SidebarWidth - m.fileModel.filePreview.width - (4 + (len(m.fileModel.filePanels)-1)*2)) / len(m.fileModel.filePanels)
 	m.filePanelFocusIndex++
-
-	m.fileModel.maxFilePanel = (m.fullWidth - common.Config.SidebarWidth - m.fileModel.filePreview.width) / 20
-
-	for i := range m.fileModel.filePanels {
-		m.fileModel.filePanels[i].searchBar.Width = m.fileModel.width - 4
-	}
+	m.setFilePanelsSize(m.fullWidth)
 	return nil
 }
 
@@ -74,6 +70,8 @@ func (m *model) closeFilePanel() {
 		return
 	}
 
+	m.fileModel.filePanels[m.filePanelFocusIndex].closeFinder()
+	m.fileModel.filePanels[m.filePanelFocusIndex].stopFuzzySearch()
 	m.fileModel.filePanels = append(m.fileModel.filePanels[:m.filePanelFocusIndex], m.fileModel.filePanels[m.filePanelFocusIndex+1:]...)
 
 	if m.fileModel.filePreview.open {
@@ -89,14 +87,8 @@ func (m *model) closeFilePanel() {
 		m.filePanelFocusIndex--
 	}
 
-	m.fileModel.width = (m.fullWidth - common.Config.SidebarWidth - m.fileModel.filePreview.width - (4 + (len(m.fileModel.filePanels)-1)*2)) / len(m.fileModel.filePanels)
 	m.fileModel.filePanels[m.filePanelFocusIndex].focusType = returnFocusType(m.focusPanel)
-
-	m.fileModel.maxFilePanel = (m.fullWidth - common.Config.SidebarWidth - m.fileModel.filePreview.width) / 20
-
-	for i := range m.fileModel.filePanels {
-		m.fileModel.filePanels[i].searchBar.Width = m.fileModel.width - 4
-	}
+	m.setFilePanelsSize(m.fullWidth)
 }
 
 func (m *model) toggleFilePreviewPanel() {
@@ -111,13 +103,7 @@ func (m *model) toggleFilePreviewPanel() {
 		}
 	}
 
-	m.fileModel.width = (m.fullWidth - common.Config.SidebarWidth - m.fileModel.filePreview.width - (4 + (len(m.fileModel.filePanels)-1)*2)) / len(m.fileModel.filePanels)
-
-	m.fileModel.maxFilePanel = (m.fullWidth - common.Config.SidebarWidth - m.fileModel.filePreview.width) / 20
-
-	for i := range m.fileModel.filePanels {
-		m.fileModel.filePanels[i].searchBar.Width = m.fileModel.width - 4
-	}
+	m.setFilePanelsSize(m.fullWidth)
 }
 
 // Focus on next file panel
@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+// Items of the select by modal, indexed by selectCriterion
+var selectCriteria = []string{ //nolint: gochecknoglobals // This is more like a const.
+	"Names matching a glob pattern",
+	"Names matching a regular expression",
+	"Directories",
+	"Images",
+	"Files modified in the last N days",
+}
+
+// Start selecting the items between the item under the cursor and the cursor,
+// or stop and keep the selected range
+func (panel *filePanel) toggleRangeSelect() {
+	if panel.rangeAnchor != "" {
+		panel.stopRangeSelect()
+		return
+	}
+	if len(panel.element) == 0 {
+		return
+	}
+	panel.rangeAnchor = panel.element[panel.cursor].location
+	panel.rangeBase = slices.Clone(panel.selected)
+	panel.updateRangeSelect()
+}
+
+func (panel *filePanel) stopRangeSelect() {
+	panel.rangeAnchor = ""
+	panel.rangeBase = nil
+}
+
+// Select the items between the anchor and the cursor, on top of the items
+// selected before the range started. The range stops once its anchor is no
+// longer listed
+func (panel *filePanel) updateRangeSelect() {
+	if panel.rangeAnchor == "" {
+		return
+	}
+	anchor := slices.IndexFunc(panel.element, func(e element) bool {
+		return e.location == panel.rangeAnchor
+	})
+	if anchor < 0 || panel.cursor >= len(panel.element) || panel.panelMode != selectMode {
+		panel.stopRangeSelect()
+		return
+	}
+	selected := slices.Clone(panel.rangeBase)
+	inBase := make(map[string]bool, len(selected))
+	for _, location := range selected {
+		inBase[location] = true
+	}
+	for i := min(anchor, panel.cursor); i <= max(anchor, panel.cursor); i++ {
+		if !inBase[panel.element[i].location] {
+			selected = append(selected, panel.element[i].location)
+		}
+	}
+	panel.selected = selected
+}
+
+// Select the listed items that are not selected, and unselect the others
+func (panel *filePanel) invertSelection() {
+	panel.stopRangeSelect()
+	selected := make(map[string]bool, len(panel.selected))
+	for _, location := range panel.selected {
+		selected[location] = true
+	}
+	inverted := make([]string, 0, len(panel.element))
+	for _, e := range panel.element {
+		if !selected[e.location] {
+			inverted = append(inverted, e.location)
+		}
+	}
+	panel.selected = inverted
+}
+
+// Locations of the listed items matching criterion. value is the pattern or
+// the number of days typed for the criteria that need one
+func (panel *filePanel) matchingItems(criterion selectCriterion, value string) ([]string, error) {
+	var matched []string
+	switch criterion {
+	case selectByGlob, selectByRegex:
+		mode := globMatch
+		if criterion == selectByRegex {
+			mode = regexMatch
+		}
+		matcher, err := newNameMatcher(mode, value)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(panel.element))
+		for i, e := range panel.element {
+			names[i] = e.name
+		}
+		for _, i := range matcher(names) {
+			matched = append(matched, panel.element[i].location)
+		}
+	case selectDirectories:
+		for _, e := range panel.element {
+			if e.directory {
+				matched = append(matched, e.location)
+			}
+		}
+	case selectImages:
+		for _, e := range panel.element {
+			if !e.directory && isImageFile(e.name) {
+				matched = append(matched, e.location)
+			}
+		}
+	case selectModifiedWithin:
+		days, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || days < 0 {
+			return nil, errors.New("invalid number of days")
+		}
+		since := time.Now().AddDate(0, 0, -days)
+		for _, e := range panel.element {
+			info := e.info
+			if info == nil {
+				if info, err = os.Lstat(e.location); err != nil {
+					continue
+				}
+			}
+			if !e.directory && info.ModTime().After(since) {
+				matched = append(matched, e.location)
+			}
+		}
+	}
+	return matched, nil
+}
+
+// Replace the selection of the focused panel by the listed items matching
+// criterion
+func (m *model) selectBy(criterion selectCriterion, value string) error {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	matched, err := panel.matchingItems(criterion, value)
+	if err != nil {
+		return err
+	}
+	panel.stopRangeSelect()
+	panel.selected = matched
+	return nil
+}
+
+func (m *model) openSelectByModal() {
+	m.selectByModal.picker.Open(selectCriteria, 0)
+}
+
+func (m *model) selectByModalKey(msg string) {
+	index, ok := m.selectByModal.picker.HandleKey(msg)
+	if !ok {
+		return
+	}
+	criterion := selectCriterion(index)
+	switch criterion {
+	case selectByGlob, selectByRegex, selectModifiedWithin:
+		m.selectByModal.criterion = criterion
+		m.selectByModal.typing = true
+		m.selectByModal.err = ""
+		m.selectByModal.textInput = common.GeneratePromptTextInput()
+		m.selectByModal.textInput.Width = common.ModalWidth - 4
+		m.selectByModal.textInput.Placeholder = selectByPlaceholder(criterion)
+		_ = m.selectByModal.textInput.Focus()
+		m.firstTextInput = true
+	case selectDirectories, selectImages:
+		// These can't fail
+		_ = m.selectBy(criterion, "")
+	}
+}
+
+// Handle a key while typing the value of a criterion. The modal stays open
+// while the value is invalid
+func (m *model) selectByValueKey(msg string) {
+	switch {
+	case slices.Contains(common.Hotkeys.CancelTyping, msg):
+		m.selectByModal.typing = false
+	case slices.Contains(common.Hotkeys.ConfirmTyping, msg):
+		if err := m.selectBy(m.selectByModal.criterion, m.selectByModal.textInput.Value()); err != nil {
+			m.selectByModal.err = err.Error()
+			return
+		}
+		m.selectByModal.typing = false
+	}
+}
+
+func selectByPlaceholder(criterion selectCriterion) string {
+	switch criterion {
+	case selectByGlob:
+		return "*.jpg"
+	case selectByRegex:
+		return `^IMG_\d+`
+	case selectModifiedWithin:
+		return "Number of days"
+	case selectDirectories, selectImages:
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func TestModel_Selection(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	for _, f := range []string{"a.jpg", "b.png", "c.txt", "d.go"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, f), nil, 0644))
+	}
+	old := time.Now().AddDate(0, 0, -30)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "c.txt"), old, old))
+	paths := func(names ...string) []string {
+		result := make([]string, len(names))
+		for i, name := range names {
+			result[i] = filepath.Join(dir, name)
+		}
+		return result
+	}
+
+	m := defaultModelConfig(false, true, []string{dir})
+	panel := &m.fileModel.filePanels[0]
+	panel.focusType = focus
+	m.getFilePanelItems()
+	require.Len(t, panel.element, 5)
+	m.changeFilePanelMode()
+	var cmd tea.Cmd
+
+	t.Run("Select a range", func(t *testing.T) {
+		panel.cursor = 1
+		m.normalAndBrowserModeKey(common.Hotkeys.FilePanelSelectRange[0])
+		require.Equal(t, []string{panel.element[1].location}, panel.selected)
+
+		m.mainKey(common.Hotkeys.ListDown[0], nil)
+		m.mainKey(common.Hotkeys.ListDown[0], nil)
+		m.updateFilePanelsState(nil, &cmd)
+		assert.Equal(t, []string{panel.element[1].location, panel.element[2].location, panel.element[3].location},
+			panel.selected)
+
+		// The range shrinks when the cursor moves back
+		m.mainKey(common.Hotkeys.ListUp[0], nil)
+		m.updateFilePanelsState(nil, &cmd)
+		assert.Len(t, panel.selected, 2)
+
+		m.normalAndBrowserModeKey(common.Hotkeys.FilePanelSelectRange[0])
+		assert.Empty(t, panel.rangeAnchor)
+		m.mainKey(common.Hotkeys.ListDown[0], nil)
+		m.updateFilePanelsState(nil, &cmd)
+		assert.Len(t, panel.selected, 2, "the range is kept once stopped")
+	})
+
+	t.Run("Invert the selection", func(t *testing.T) {
+		m.normalAndBrowserModeKey(common.Hotkeys.FilePanelInvertSelection[0])
+		assert.Equal(t, []string{panel.element[0].location, panel.element[3].location, panel.element[4].location},
+			panel.selected)
+	})
+
+	t.Run("Select by criterion", func(t *testing.T) {
+		m.normalAndBrowserModeKey(common.Hotkeys.FilePanelSelectBy[0])
+		require.True(t, m.selectByModal.picker.IsOpen())
+		m.selectByModalKey(common.Hotkeys.ListDown[0])
+		m.selectByModalKey(common.Hotkeys.ListDown[0])
+		m.selectByModalKey(common.Hotkeys.ListDown[0])
+		m.selectByModalKey(common.Hotkeys.Confirm[0])
+		assert.False(t, m.selectByModal.picker.IsOpen())
+		assert.ElementsMatch(t, paths("a.jpg", "b.png"), panel.selected)
+
+		require.NoError(t, m.selectBy(selectDirectories, ""))
+		assert.Equal(t, paths("sub"), panel.selected)
+		require.NoError(t, m.selectBy(selectByGlob, "*.go"))
+		assert.Equal(t, paths("d.go"), panel.selected)
+		require.NoError(t, m.selectBy(selectByRegex, `^[ab]\.`))
+		assert.ElementsMatch(t, paths("a.jpg", "b.png"), panel.selected)
+		require.NoError(t, m.selectBy(selectModifiedWithin, "7"))
+		assert.ElementsMatch(t, paths("a.jpg", "b.png", "d.go"), panel.selected)
+	})
+
+	t.Run("Invalid values keep the modal open", func(t *testing.T) {
+		m.openSelectByModal()
+		m.selectByModalKey(common.Hotkeys.Confirm[0])
+		require.True(t, m.selectByModal.typing)
+		m.selectByModal.textInput.SetValue("[")
+		m.selectByValueKey(common.Hotkeys.ConfirmTyping[0])
+		assert.True(t, m.selectByModal.typing)
+		assert.NotEmpty(t, m.selectByModal.err)
+		assert.ElementsMatch(t, paths("a.jpg", "b.png", "d.go"), panel.selected)
+
+		m.selectByModal.textInput.SetValue("*.txt")
+		m.selectByValueKey(common.Hotkeys.ConfirmTyping[0])
+		assert.False(t, m.selectByModal.typing)
+		assert.Equal(t, paths("c.txt"), panel.selected)
+
+		_, err := panel.matchingItems(selectModifiedWithin, "a week")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,201 @@
+package internal
+
+import (
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/tags"
+)
+
+// In the order they are offered in the tag picker
+var colorTags = []colorTag{ //nolint: gochecknoglobals // This is more like a const.
+	{"red", "#ed8796"},
+	{"orange", "#f5a97f"},
+	{"yellow", "#eed49f"},
+	{"green", "#a6da95"},
+	{"blue", "#8aadf4"},
+	{"purple", "#c6a0f6"},
+	{"gray", "#939ab7"},
+}
+
+// Labels of tags shown beside the name of an item: a coloured dot for colour
+// tags and the name for the others. Labels that don't fit in maxWidth are
+// left out
+func tagLabels(tags []string, maxWidth int) string {
+	labels := ""
+	width := 0
+	for _, tag := range tags {
+		label := common.TagStyle.Render("#" + tag)
+		if index := slices.IndexFunc(colorTags, func(c colorTag) bool { return c.tag == tag }); index >= 0 {
+			label = lipgloss.NewStyle().Foreground(colorTags[index].color).Background(common.FilePanelBGColor).Render("●")
+		}
+		if labels != "" {
+			label = common.FilePanelStyle.Render(" ") + label
+		}
+		if width+lipgloss.Width(label) > maxWidth {
+			break
+		}
+		labels += label
+		width += lipgloss.Width(label)
+	}
+	return labels
+}
+
+// Colour tags first, then the other tags in use
+func (m *model) tagChoices() []string {
+	choices := make([]string, 0, len(colorTags))
+	for _, c := range colorTags {
+		choices = append(choices, c.tag)
+	}
+	for _, tag := range m.fileTags.All() {
+		if !slices.Contains(choices, tag) {
+			choices = append(choices, tag)
+		}
+	}
+	return choices
+}
+
+// Items of the tag picker. A tag is checked when all the edited items carry
+// it, and partially checked when some of them do
+func (m *model) tagModalItems() []string {
+	items := make([]string, len(m.tagModal.tags))
+	for i, tag := range m.tagModal.tags {
+		count := 0
+		for _, target := range m.tagModal.targets {
+			if m.fileTags.Has(target, tag) {
+				count++
+			}
+		}
+		switch count {
+		case 0:
+			items[i] = "[ ] " + tag
+		case len(m.tagModal.targets):
+			items[i] = "[x] " + tag
+		default:
+			items[i] = "[-] " + tag
+		}
+	}
+	return items
+}
+
+// Open the tag picker for the selected items, or the item under the cursor.
+// Typing a name that matches no tag creates a new one
+func (m *model) openTagModal() {
+	targets := m.fileModel.filePanels[m.filePanelFocusIndex].actionTargets()
+	if len(targets) == 0 {
+		return
+	}
+	m.tagModal.targets = targets
+	m.tagModal.tags = m.tagChoices()
+	m.tagModal.picker.Open(m.tagModalItems(), 0)
+	m.firstTextInput = true
+}
+
+func (m *model) tagModalKey(msg string) {
+	query := strings.TrimSpace(m.tagModal.picker.Query())
+	index, ok := m.tagModal.picker.HandleKey(msg)
+	switch {
+	case ok:
+		m.toggleTag(m.tagModal.tags[index])
+	case slices.Contains(common.Hotkeys.ConfirmTyping, msg) && query != "":
+		// No tag matches the query
+		m.toggleTag(query)
+	}
+}
+
+// Remove tag from the edited items if they all carry it, add it to all of
+// them otherwise
+func (m *model) toggleTag(tag string) {
+	targets := m.tagModal.targets
+	m.tagModal.targets = nil
+	remove := true
+	for _, target := range targets {
+		if !m.fileTags.Has(target, tag) {
+			remove = false
+			break
+		}
+	}
+	for _, target := range targets {
+		var err error
+		if remove {
+			err = m.fileTags.Remove(target, tag)
+		} else {
+			err = m.fileTags.Add(target, tag)
+		}
+		if err != nil {
+			slog.Error("Error while tagging item", "path", target, "tag", tag, "error", err)
+			return
+		}
+	}
+}
+
+// Filter the focused panel by a tag picked from the tags in use, or clear
+// its filter
+func (m *model) toggleTagFilter() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	if panel.tagFilter != "" {
+		panel.setTagFilter("")
+		return
+	}
+	m.tagFilterModal.Open(m.fileTags.All(), 0)
+}
+
+func (m *model) tagFilterModalKey(msg string) {
+	tags := m.tagFilterModal.Items()
+	index, ok := m.tagFilterModal.HandleKey(msg)
+	if !ok {
+		return
+	}
+	m.fileModel.filePanels[m.filePanelFocusIndex].setTagFilter(tags[index])
+}
+
+func (panel *filePanel) setTagFilter(tag string) {
+	panel.tagFilter = tag
+	panel.cursor = 0
+	panel.render = 0
+	panel.resetElements()
+}
+
+// Read the tags attributes of the items below the location of the panel
+// again the next time they are needed, as other programs could have changed
+// them since the location was last read
+func (m *model) uncheckPanelTags(panel *filePanel) {
+	m.fileTags.Uncheck(func(path string) bool {
+		return isBelow(panel.location, path)
+	})
+}
+
+// Read the tags attributes of the items at paths, changed in the watched
+// directory dir, again the next time they are needed. All the items of dir
+// are read again when dir itself is part of paths
+func (m *model) uncheckChangedTags(dir string, paths []string) {
+	changed := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		changed[path] = true
+	}
+	m.fileTags.Uncheck(func(path string) bool {
+		return changed[path] || (changed[dir] && filepath.Dir(path) == dir)
+	})
+}
+
+// Forget the tags attributes read for the items that are no longer below the
+// location of any panel, so that they don't pile up while browsing
+func (m *model) pruneCheckedTags() {
+	m.fileTags.Uncheck(func(path string) bool {
+		return !slices.ContainsFunc(m.fileModel.filePanels, func(panel filePanel) bool {
+			return isBelow(panel.location, path)
+		})
+	})
+}
+
+// Items of elements carrying tag in store
+func filterTagged(elements []element, tag string, store *tags.Store) []element {
+	return slices.DeleteFunc(elements, func(e element) bool {
+		return !store.Has(e.location, tag)
+	})
+}
@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/tags"
+)
+
+func TestModel_Tags(t *testing.T) {
+	dir := t.TempDir()
+	fileA := filepath.Join(dir, "a.txt")
+	fileB := filepath.Join(dir, "b.txt")
+	require.NoError(t, os.WriteFile(fileA, nil, 0644))
+	require.NoError(t, os.WriteFile(fileB, nil, 0644))
+	m := defaultModelConfig(false, true, []string{dir})
+	m.fileTags = tags.New(filepath.Join(t.TempDir(), "tags.json"))
+	panel := &m.fileModel.filePanels[0]
+	panel.element = m.dirElements(panel)
+
+	t.Run("Tag the item under the cursor", func(t *testing.T) {
+		m.mainKey(common.Hotkeys.OpenTagMenu[0], nil)
+		require.True(t, m.tagModal.picker.IsOpen())
+		assert.Equal(t, "[ ] red", m.tagModal.picker.Items()[0])
+		m.tagModalKey(common.Hotkeys.ConfirmTyping[0])
+		assert.False(t, m.tagModal.picker.IsOpen())
+		assert.Equal(t, []string{"red"}, m.fileTags.Tags(fileA))
+
+		// Typing a name matching no tag creates it
+		m.openTagModal()
+		assert.Equal(t, "[x] red", m.tagModal.picker.Items()[0])
+		m.tagModal.picker.UpdateState(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("todo")})
+		m.tagModalKey(common.Hotkeys.ConfirmTyping[0])
+		assert.Equal(t, []string{"red", "todo"}, m.fileTags.Tags(fileA))
+		assert.Empty(t, m.fileTags.Tags(fileB))
+	})
+
+	t.Run("Toggle a tag on the selected items", func(t *testing.T) {
+		panel.panelMode = selectMode
+		panel.selected = []string{fileA, fileB}
+		m.openTagModal()
+		assert.Equal(t, "[-] red", m.tagModal.picker.Items()[0])
+		m.tagModalKey(common.Hotkeys.ConfirmTyping[0])
+		assert.True(t, m.fileTags.Has(fileB, "red"))
+
+		m.openTagModal()
+		assert.Equal(t, "[x] red", m.tagModal.picker.Items()[0])
+		m.tagModalKey(common.Hotkeys.ConfirmTyping[0])
+		assert.False(t, m.fileTags.Has(fileA, "red"))
+		assert.False(t, m.fileTags.Has(fileB, "red"))
+		panel.panelMode = browserMode
+		panel.selected = nil
+	})
+
+	t.Run("Filter the panel by tag", func(t *testing.T) {
+		m.mainKey(common.Hotkeys.FilterByTag[0], nil)
+		require.True(t, m.tagFilterModal.IsOpen())
+		assert.Equal(t, []string{"todo"}, m.tagFilterModal.Items())
+		m.tagFilterModalKey(common.Hotkeys.Confirm[0])
+		assert.Equal(t, "todo", panel.tagFilter)
+		assert.Len(t, m.filterListing(panel, []element{{location: fileA}, {location: fileB}}), 1,
+			"changed items are filtered too")
+
+		elements := m.dirElements(panel)
+		require.Len(t, elements, 1)
+		assert.Equal(t, fileA, elements[0].location)
+
+		m.mainKey(common.Hotkeys.FilterByTag[0], nil)
+		assert.False(t, m.tagFilterModal.IsOpen())
+		assert.Empty(t, panel.tagFilter)
+		assert.Len(t, m.dirElements(panel), 2)
+	})
+
+	t.Run("Tags follow renamed items", func(t *testing.T) {
+		panel.element = m.dirElements(panel)
+		panel.cursor = 0
+		panel.rename.SetValue("c.txt")
+		m.confirmRename()
+		assert.Equal(t, []string{"todo"}, m.fileTags.Tags(filepath.Join(dir, "c.txt")))
+		assert.Equal(t, []string{filepath.Join(dir, "c.txt")}, m.fileTags.Files())
+	})
+}
+
+func TestTagLabels(t *testing.T) {
+	assert.Empty(t, tagLabels(nil, 10))
+	assert.Equal(t, 7, lipgloss.Width(tagLabels([]string{"red", "todo"}, 10)))
+	assert.Equal(t, 1, lipgloss.Width(tagLabels([]string{"red", "todo"}, 6)), "labels that don't fit are left out")
+	assert.Empty(t, tagLabels([]string{"todo"}, 4))
+}
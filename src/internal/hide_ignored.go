@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"slices"
+	"time"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ignore"
+)
+
+// Matcher of the items the panel hides, nil when it shows them
+func (panel *filePanel) ignoreMatcher() *ignore.Matcher {
+	if !panel.hideIgnored {
+		return nil
+	}
+	return ignore.New(common.Config.HiddenPatterns)
+}
+
+// Elements that are not ignored, in the same order
+func filterIgnored(elements []element, ignored *ignore.Matcher) []element {
+	if ignored == nil {
+		return elements
+	}
+	return slices.DeleteFunc(elements, func(e element) bool {
+		return ignored.Ignored(e.location, e.directory)
+	})
+}
+
+// Hide or show the items of the focused panel matched by ignore files and
+// hidden patterns
+func (m *model) toggleHideIgnored() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.hideIgnored = !panel.hideIgnored
+	// The parent column lists the parent directory with the same setting
+	m.fileModel.parentColumn.lastTimeGetElement = time.Time{}
+	if panel.finder.active {
+		return
+	}
+	location := panel.location
+	if len(panel.element) > 0 {
+		location = panel.element[panel.cursor].location
+	}
+	m.reloadPanelElements(panel, location)
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func TestModel_HideIgnored(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"node_modules/pkg", "src/build"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+	}
+	for _, f := range []string{"node_modules/pkg/index.js", "src/main.go", "src/debug.log", "src/build/out.bin"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, f), nil, 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".ignore"), []byte("*.log\nbuild/\n"), 0644))
+
+	m := defaultModelConfig(false, true, []string{dir})
+	panel := &m.fileModel.filePanels[0]
+	panel.focusType = focus
+	m.getFilePanelItems()
+	require.Len(t, panel.element, 2)
+
+	m.mainKey(common.Hotkeys.ToggleIgnored[0], nil)
+	require.True(t, panel.hideIgnored)
+	assert.True(t, panel.updatesInPlace(), "changed items are filtered too")
+	require.Len(t, panel.element, 1)
+	assert.Equal(t, filepath.Join(dir, "src"), panel.element[0].location)
+
+	// Ignored items are skipped in every view
+	m.toggleFlatten()
+	m.handleChannelMessage(receiveMessage(t, sendFlatElements))
+	var names []string
+	for _, e := range panel.element {
+		names = append(names, e.name)
+	}
+	assert.Equal(t, []string{"src", filepath.Join("src", "main.go")}, names)
+	m.toggleFlatten()
+
+	m.mainKey(common.Hotkeys.ToggleIgnored[0], nil)
+	assert.False(t, panel.hideIgnored)
+	assert.True(t, panel.updatesInPlace())
+	assert.Len(t, panel.element, 2)
+}
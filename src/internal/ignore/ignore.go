@@ -0,0 +1,222 @@
+package ignore
+
+import (
+	"bufio"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Ignore files read in each directory, by increasing precedence. Files of
+// deeper directories take precedence over the ones of their ancestors
+var ignoreFiles = []string{".gitignore", ".ignore"} //nolint: gochecknoglobals // This is more like a const.
+
+// IsIgnoreFile returns whether the file at path holds ignore patterns, so that
+// changing it can change which items are ignored
+func IsIgnoreFile(path string) bool {
+	return slices.Contains(ignoreFiles, filepath.Base(path))
+}
+
+// Pattern of an ignore file, with the syntax of gitignore
+type rule struct {
+	// Directory of the ignore file, anchored patterns are relative to it
+	base    string
+	pattern *regexp.Regexp
+	// Matched against the path relative to base instead of the name
+	anchored bool
+	// Only matches directories
+	dirOnly bool
+	// Includes again what a previous pattern ignored
+	negate bool
+}
+
+// Ignore rules applying in a directory
+type dirRules struct {
+	rules []rule
+	// Inside a git work tree. .gitignore files outside of work trees are not
+	// followed, as by git itself
+	inWorkTree bool
+}
+
+// Tells whether items are ignored, by the ignore files of their directory and
+// its ancestors or by name. Ignore files are read once per directory, so a
+// Matcher should not outlive a listing. All methods are safe to call on a nil
+// *Matcher, which ignores nothing
+type Matcher struct {
+	// Glob patterns of names of items that are always ignored
+	namePatterns []string
+	dirs         map[string]dirRules
+}
+
+func New(namePatterns []string) *Matcher {
+	return &Matcher{
+		namePatterns: namePatterns,
+		dirs:         make(map[string]dirRules),
+	}
+}
+
+// Whether the item at path is ignored. Items in ignored directories are only
+// ignored if a pattern matches them too, so that the content of an ignored
+// directory is listed when it is opened
+func (m *Matcher) Ignored(path string, isDir bool) bool {
+	if m == nil {
+		return false
+	}
+	name := filepath.Base(path)
+	for _, pattern := range m.namePatterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	ignored := false
+	for _, r := range m.rules(filepath.Dir(path)).rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		subject := name
+		if r.anchored {
+			rel, err := filepath.Rel(r.base, path)
+			if err != nil {
+				continue
+			}
+			subject = filepath.ToSlash(rel)
+		}
+		if r.pattern.MatchString(subject) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// Rules applying in dir: the ones of its ancestors followed by its own
+func (m *Matcher) rules(dir string) dirRules {
+	if d, ok := m.dirs[dir]; ok {
+		return d
+	}
+	var d dirRules
+	if parent := filepath.Dir(dir); parent != dir {
+		d = m.rules(parent)
+		// Rules of the ancestors are shared, they must not be appended to
+		d.rules = d.rules[:len(d.rules):len(d.rules)]
+	}
+	if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+		d.inWorkTree = true
+		d.rules = append(d.rules, readRules(dir, filepath.Join(dir, ".git", "info", "exclude"))...)
+	}
+	for _, file := range ignoreFiles {
+		if file == ".gitignore" && !d.inWorkTree {
+			continue
+		}
+		d.rules = append(d.rules, readRules(dir, filepath.Join(dir, file))...)
+	}
+	m.dirs[dir] = d
+	return d
+}
+
+// Rules of the ignore file at path, relative to base. Missing files have none
+func readRules(base string, path string) []rule {
+	file, err := os.Open(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, os.ErrPermission) {
+			slog.Debug("Error while reading ignore file", "path", path, "error", err)
+		}
+		return nil
+	}
+	defer file.Close()
+
+	var rules []rule
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if r, ok := parseRule(base, scanner.Text()); ok {
+			rules = append(rules, r)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		slog.Debug("Error while reading ignore file", "path", path, "error", err)
+	}
+	return rules
+}
+
+// Parse a line of an ignore file. Blank lines and comments are not rules
+func parseRule(base string, line string) (rule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	// Trailing spaces are ignored unless escaped
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule{}, false
+	}
+	r := rule{base: base}
+	if strings.HasPrefix(line, "!") {
+		r.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, "\\!") || strings.HasPrefix(line, "\\#") {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	// A slash at the beginning or in the middle anchors the pattern
+	if strings.Contains(line, "/") {
+		r.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	if line == "" {
+		return rule{}, false
+	}
+	pattern, err := regexp.Compile(globToRegexp(line))
+	if err != nil {
+		slog.Debug("Skipping invalid ignore pattern", "pattern", line, "error", err)
+		return rule{}, false
+	}
+	r.pattern = pattern
+	return r, true
+}
+
+// Regular expression matching the same slash separated paths as a gitignore
+// glob, where ** matches any number of directories
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**") && i+2 == len(glob):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '\\' && i+1 < len(glob):
+			i++
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				break
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			// Bytes of multibyte characters are copied as they are
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
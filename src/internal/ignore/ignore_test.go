@@ -0,0 +1,117 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestMatcher(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git", "info"), 0755))
+	writeFile(t, filepath.Join(repo, ".git", "info", "exclude"), "excluded.txt\n")
+	writeFile(t, filepath.Join(repo, ".gitignore"), "# build output\nnode_modules/\n*.log\n!keep.log\n/build\ndocs/**/*.tmp\n")
+	writeFile(t, filepath.Join(repo, "sub", ".gitignore"), "local.txt\n!excluded.txt\n")
+	writeFile(t, filepath.Join(repo, "sub", ".ignore"), "secret  \n")
+	// Outside of a work tree only .ignore files are followed
+	writeFile(t, filepath.Join(root, ".gitignore"), "*.md\n")
+	writeFile(t, filepath.Join(root, ".ignore"), "*.bak\n")
+
+	m := New([]string{"__pycache__"})
+	testcases := []struct {
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{"repo/node_modules", true, true},
+		{"repo/sub/node_modules", true, true},
+		{"repo/node_modules", false, false},
+		{"repo/error.log", false, true},
+		{"repo/sub/error.log", false, true},
+		{"repo/keep.log", false, false},
+		{"repo/build", true, true},
+		{"repo/sub/build", true, false},
+		{"repo/docs/a/b/c.tmp", false, true},
+		{"repo/docs/c.tmp", false, true},
+		{"repo/c.tmp", false, false},
+		{"repo/excluded.txt", false, true},
+		{"repo/sub/excluded.txt", false, false},
+		{"repo/sub/local.txt", false, true},
+		{"repo/local.txt", false, false},
+		{"repo/sub/secret", false, true},
+		{"repo/sub/__pycache__", true, true},
+		{"repo/readme.md", false, false},
+		{"repo/old.bak", false, true},
+		{"notes.md", false, false},
+		{"main.go", false, false},
+	}
+	for _, tt := range testcases {
+		assert.Equal(t, tt.expected, m.Ignored(filepath.Join(root, tt.path), tt.isDir), tt.path)
+	}
+}
+
+func TestIsIgnoreFile(t *testing.T) {
+	assert.True(t, IsIgnoreFile("/src/.gitignore"))
+	assert.True(t, IsIgnoreFile(".ignore"))
+	assert.False(t, IsIgnoreFile("/src/gitignore.txt"))
+}
+
+func TestNilMatcher(t *testing.T) {
+	var m *Matcher
+	assert.False(t, m.Ignored("/tmp/node_modules", true))
+}
+
+func TestParseRule(t *testing.T) {
+	for _, line := range []string{"", "   ", "# comment", "/", "!"} {
+		_, ok := parseRule("/", line)
+		assert.False(t, ok, line)
+	}
+	r, ok := parseRule("/", "\\#not-a-comment")
+	require.True(t, ok)
+	assert.True(t, r.pattern.MatchString("#not-a-comment"))
+	r, ok = parseRule("/", "!/logs/ ")
+	require.True(t, ok)
+	assert.True(t, r.negate)
+	assert.True(t, r.anchored)
+	assert.True(t, r.dirOnly)
+	assert.True(t, r.pattern.MatchString("logs"))
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	testcases := []struct {
+		glob    string
+		match   []string
+		noMatch []string
+	}{
+		{"*.go", []string{"main.go", ".go"}, []string{"main.go.bak", "a/main.go"}},
+		{"a?c", []string{"abc"}, []string{"ac", "a/c"}},
+		{"**/foo", []string{"foo", "a/foo", "a/b/foo"}, []string{"afoo"}},
+		{"a/**/b", []string{"a/b", "a/x/b", "a/x/y/b"}, []string{"ab", "b"}},
+		{"abc/**", []string{"abc/x", "abc/x/y"}, []string{"abc"}},
+		{"[a-c]x", []string{"bx"}, []string{"dx"}},
+		{"[!a-c]x", []string{"dx"}, []string{"bx"}},
+		{"a.b+c", []string{"a.b+c"}, []string{"axb+c"}},
+		{"\\*", []string{"*"}, []string{"a"}},
+		{"café*", []string{"café.txt"}, []string{"cafe.txt"}},
+	}
+	for _, tt := range testcases {
+		re := regexp.MustCompile(globToRegexp(tt.glob))
+		for _, s := range tt.match {
+			assert.True(t, re.MatchString(s), "%q should match %q", tt.glob, s)
+		}
+		for _, s := range tt.noMatch {
+			assert.False(t, re.MatchString(s), "%q should not match %q", tt.glob, s)
+		}
+	}
+}
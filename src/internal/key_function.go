@@ -58,6 +58,15 @@ func (m *model) mainKey(msg string, cmd tea.Cmd) tea.Cmd {
 	case slices.Contains(common.Hotkeys.ChangePanelMode, msg):
 		m.changeFilePanelMode()
 
+	case slices.Contains(common.Hotkeys.ToggleTreeView, msg):
+		m.toggleTreeView()
+
+	case slices.Contains(common.Hotkeys.ToggleFlatten, msg):
+		m.toggleFlatten()
+
+	case slices.Contains(common.Hotkeys.ToggleDetailView, msg):
+		m.toggleDetailView()
+
 	case slices.Contains(common.Hotkeys.NextFilePanel, msg):
 		m.nextFilePanel()
 
@@ -75,6 +84,42 @@ func (m *model) mainKey(msg string, cmd tea.Cmd) tea.Cmd {
 	case slices.Contains(common.Hotkeys.ToggleFilePreviewPanel, msg):
 		m.toggleFilePreviewPanel()
 
+	case slices.Contains(common.Hotkeys.ToggleMillerColumns, msg):
+		m.toggleMillerColumns()
+
+	case slices.Contains(common.Hotkeys.OpenNewTab, msg):
+		m.openNewTab()
+
+	case slices.Contains(common.Hotkeys.CloseTab, msg):
+		m.closeTab()
+
+	case slices.Contains(common.Hotkeys.NextTab, msg):
+		m.nextTab()
+
+	case slices.Contains(common.Hotkeys.PreviousTab, msg):
+		m.previousTab()
+
+	case slices.Contains(common.Hotkeys.HistoryBack, msg):
+		m.historyBack()
+
+	case slices.Contains(common.Hotkeys.HistoryForward, msg):
+		m.historyForward()
+
+	case slices.Contains(common.Hotkeys.OpenHistoryMenu, msg):
+		m.openHistoryModal()
+
+	case slices.Contains(common.Hotkeys.OpenJumpMenu, msg):
+		m.openJumpModal()
+
+	case slices.Contains(common.Hotkeys.SetMark, msg):
+		m.pendingMark = setMarkPending
+
+	case slices.Contains(common.Hotkeys.JumpToMark, msg):
+		m.pendingMark = jumpToMarkPending
+
+	case slices.Contains(common.Hotkeys.OpenMarksMenu, msg):
+		m.openMarksModal()
+
 	case slices.Contains(common.Hotkeys.FocusOnSidebar, msg):
 		m.focusOnSideBar()
 
@@ -99,6 +144,8 @@ func (m *model) mainKey(msg string, cmd tea.Cmd) tea.Cmd {
 
 	case slices.Contains(common.Hotkeys.ToggleDotFile, msg):
 		m.toggleDotFileController()
+	case slices.Contains(common.Hotkeys.ToggleIgnored, msg):
+		m.toggleHideIgnored()
 
 	case slices.Contains(common.Hotkeys.ToggleFooter, msg):
 		m.toggleFooterController()
@@ -113,6 +160,32 @@ func (m *model) mainKey(msg string, cmd tea.Cmd) tea.Cmd {
 			m.compressFile()
 		}()
 
+	case slices.Contains(common.Hotkeys.GitStage, msg):
+		targets := m.fileModel.filePanels[m.filePanelFocusIndex].actionTargets()
+		go func() {
+			m.runGitAction(gitStageAction(), targets)
+		}()
+
+	case slices.Contains(common.Hotkeys.GitUnstage, msg):
+		targets := m.fileModel.filePanels[m.filePanelFocusIndex].actionTargets()
+		go func() {
+			m.runGitAction(gitUnstageAction(), targets)
+		}()
+
+	case slices.Contains(common.Hotkeys.GitDiscard, msg):
+		m.gitDiscardWarn()
+
+	case slices.Contains(common.Hotkeys.GitDiff, msg):
+		targets, location := m.gitDiffTargets()
+		go func() {
+			readGitDiff(targets, location)
+		}()
+
+	case slices.Contains(common.Hotkeys.OpenTagMenu, msg):
+		m.openTagModal()
+	case slices.Contains(common.Hotkeys.FilterByTag, msg):
+		m.toggleTagFilter()
+
 	case slices.Contains(common.Hotkeys.OpenCommandLine, msg):
 		m.promptModal.Open(true)
 	case slices.Contains(common.Hotkeys.OpenSPFPrompt, msg):
@@ -127,9 +200,16 @@ func (m *model) mainKey(msg string, cmd tea.Cmd) tea.Cmd {
 	case slices.Contains(common.Hotkeys.ToggleReverseSort, msg):
 		m.toggleReverseSort()
 
+	case slices.Contains(common.Hotkeys.ToggleDirectoriesFirst, msg):
+		m.toggleDirectoriesFirst()
+
 	case slices.Contains(common.Hotkeys.OpenFileWithEditor, msg):
 		cmd = m.openFileWithEditor()
 
+	// Content search results open in the editor at the matching line
+	case slices.Contains(common.Hotkeys.Confirm, msg) && m.cursorOnContentMatch():
+		cmd = m.openFileWithEditor()
+
 	case slices.Contains(common.Hotkeys.OpenCurrentDirectoryWithEditor, msg):
 		cmd = m.openDirectoryWithEditor()
 
@@ -173,6 +253,12 @@ func (m *model) normalAndBrowserModeKey(msg string) {
 			m.copyMultipleItem(true)
 		case slices.Contains(common.Hotkeys.FilePanelSelectAllItem, msg):
 			m.selectAllItem()
+		case slices.Contains(common.Hotkeys.FilePanelSelectRange, msg):
+			m.fileModel.filePanels[m.filePanelFocusIndex].toggleRangeSelect()
+		case slices.Contains(common.Hotkeys.FilePanelInvertSelection, msg):
+			m.fileModel.filePanels[m.filePanelFocusIndex].invertSelection()
+		case slices.Contains(common.Hotkeys.FilePanelSelectBy, msg):
+			m.openSelectByModal()
 		}
 		return
 	}
@@ -182,6 +268,8 @@ func (m *model) normalAndBrowserModeKey(msg string) {
 		m.enterPanel()
 	case slices.Contains(common.Hotkeys.ParentDirectory, msg):
 		m.parentDirectory()
+	case slices.Contains(common.Hotkeys.ToggleTreeNode, msg):
+		m.toggleTreeNode()
 	case slices.Contains(common.Hotkeys.DeleteItems, msg):
 		go func() {
 			m.deleteItemWarn()
@@ -194,6 +282,10 @@ func (m *model) normalAndBrowserModeKey(msg string) {
 		m.panelItemRename()
 	case slices.Contains(common.Hotkeys.SearchBar, msg):
 		m.searchBarFocus()
+	case slices.Contains(common.Hotkeys.FindRecursively, msg):
+		m.openFindBar(false)
+	case slices.Contains(common.Hotkeys.SearchContent, msg):
+		m.openFindBar(true)
 	case slices.Contains(common.Hotkeys.CopyPath, msg):
 		m.copyPath()
 	case slices.Contains(common.Hotkeys.CopyPWD, msg):
@@ -250,6 +342,11 @@ func (m *model) warnModalOpenKey(msg string) {
 			}
 		case confirmRenameItem:
 			m.confirmRename()
+		case confirmGitDiscard:
+			targets := m.fileModel.filePanels[m.filePanelFocusIndex].actionTargets()
+			go func() {
+				m.runGitAction(gitDiscardAction(), targets)
+			}()
 		}
 	}
 }
@@ -313,6 +410,12 @@ func (m *model) focusOnSearchbarKey(msg string) {
 		m.cancelSearch()
 	case slices.Contains(common.Hotkeys.ConfirmTyping, msg):
 		m.confirmSearch()
+	case slices.Contains(common.Hotkeys.NextSearchMode, msg):
+		panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+		panel.searchMode = panel.searchMode.next(false)
+	case slices.Contains(common.Hotkeys.ToggleSearchPin, msg):
+		panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+		panel.searchPinned = !panel.searchPinned
 	}
 }
 
@@ -0,0 +1,119 @@
+package marks
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+// A location saved under a single letter label
+type Mark struct {
+	Location string `json:"location"`
+	Name     string `json:"name"`
+}
+
+// Marks of the user, saved to file on every change. A nil *Store is usable,
+// it has no marks and refuses new ones
+type Store struct {
+	file  string
+	marks map[string]Mark
+}
+
+func New(file string) *Store {
+	return &Store{
+		file:  file,
+		marks: make(map[string]Mark),
+	}
+}
+
+// Load the marks saved in file, skipping invalid labels. No marks when the
+// file can't be read
+func Load(file string) *Store {
+	s := New(file)
+	var marks map[string]Mark
+	if !utils.ReadJSONFile(file, &marks) {
+		return s
+	}
+	for label, mark := range marks {
+		if IsValidLabel(label) {
+			s.marks[label] = mark
+		}
+	}
+	return s
+}
+
+// Marks are labeled by a single ASCII letter, as in vim
+func IsValidLabel(label string) bool {
+	return len(label) == 1 && strings.ContainsAny(label, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+}
+
+func (s *Store) save() error {
+	return utils.WriteJSONFile(s.file, s.marks)
+}
+
+// Set mark label to location, replacing the previous mark with that label
+func (s *Store) Set(label string, location string) error {
+	if s == nil {
+		return errors.New("marks are not available")
+	}
+	if !IsValidLabel(label) {
+		return fmt.Errorf("invalid mark label %q", label)
+	}
+	s.marks[label] = Mark{Location: location, Name: filepath.Base(location)}
+	return s.save()
+}
+
+func (s *Store) Get(label string) (Mark, bool) {
+	if s == nil {
+		return Mark{}, false
+	}
+	mark, ok := s.marks[label]
+	return mark, ok
+}
+
+func (s *Store) Delete(label string) error {
+	if s == nil {
+		return nil
+	}
+	if _, ok := s.marks[label]; !ok {
+		return nil
+	}
+	delete(s.marks, label)
+	return s.save()
+}
+
+func (s *Store) Rename(label string, name string) error {
+	if s == nil {
+		return nil
+	}
+	mark, ok := s.marks[label]
+	if !ok {
+		return fmt.Errorf("no mark %q", label)
+	}
+	mark.Name = name
+	s.marks[label] = mark
+	return s.save()
+}
+
+// Labels of all marks, lowercase ones first
+func (s *Store) Labels() []string {
+	if s == nil {
+		return nil
+	}
+	labels := make([]string, 0, len(s.marks))
+	for label := range s.marks {
+		labels = append(labels, label)
+	}
+	slices.SortFunc(labels, func(a string, b string) int {
+		if cmp := strings.Compare(strings.ToLower(a), strings.ToLower(b)); cmp != 0 {
+			return cmp
+		}
+		// Lowercase letters sort after uppercase in ASCII
+		return -strings.Compare(a, b)
+	})
+	return labels
+}
@@ -0,0 +1,63 @@
+package marks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidLabel(t *testing.T) {
+	assert.True(t, IsValidLabel("a"))
+	assert.True(t, IsValidLabel("Z"))
+	assert.False(t, IsValidLabel(""))
+	assert.False(t, IsValidLabel("1"))
+	assert.False(t, IsValidLabel("ab"))
+	assert.False(t, IsValidLabel("é"))
+}
+
+func TestStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "marks.json")
+	s := Load(file)
+	assert.Empty(t, s.Labels())
+
+	require.NoError(t, s.Set("b", "/home/user/projects"))
+	require.NoError(t, s.Set("a", "/tmp"))
+	require.NoError(t, s.Set("A", "/etc/hosts"))
+	assert.Error(t, s.Set("1", "/tmp"))
+	require.NoError(t, s.Rename("b", "work"))
+	assert.Error(t, s.Rename("c", "nothing"))
+	assert.Equal(t, []string{"a", "A", "b"}, s.Labels())
+
+	// Changes are saved immediately
+	loaded := Load(file)
+	mark, ok := loaded.Get("b")
+	require.True(t, ok)
+	assert.Equal(t, Mark{Location: "/home/user/projects", Name: "work"}, mark)
+	mark, _ = loaded.Get("A")
+	assert.Equal(t, "hosts", mark.Name)
+
+	require.NoError(t, loaded.Delete("a"))
+	require.NoError(t, loaded.Delete("a"))
+	assert.Equal(t, []string{"A", "b"}, Load(file).Labels())
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "marks.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"a":{"location":"/tmp"},"ab":{"location":"/"}}`), 0644))
+	assert.Equal(t, []string{"a"}, Load(file).Labels())
+
+	require.NoError(t, os.WriteFile(file, []byte("not json"), 0644))
+	assert.Empty(t, Load(file).Labels())
+}
+
+func TestNilStore(t *testing.T) {
+	var s *Store
+	_, ok := s.Get("a")
+	assert.False(t, ok)
+	assert.Nil(t, s.Labels())
+	assert.Error(t, s.Set("a", "/tmp"))
+	assert.NoError(t, s.Delete("a"))
+}
@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+func (f matchMode) String() string {
+	switch f {
+	case globMatch:
+		return "glob"
+	case regexMatch:
+		return "regex"
+	case fuzzyMatch:
+		return "fuzzy"
+	case exactMatch:
+		return "exact"
+	}
+	return "unknown"
+}
+
+// Mode following f. File content can only be matched exactly or by regex
+func (f matchMode) next(content bool) matchMode {
+	if content {
+		if f == exactMatch {
+			return regexMatch
+		}
+		return exactMatch
+	}
+	switch f {
+	case fuzzyMatch:
+		return globMatch
+	case globMatch:
+		return regexMatch
+	case regexMatch:
+		return exactMatch
+	case exactMatch:
+	}
+	return fuzzyMatch
+}
+
+// Prompt of the search bar and the find bar, showing the match mode
+func searchPrompt(mode matchMode, pinned bool) string {
+	prompt := icon.Search + icon.Space + mode.String()
+	if pinned {
+		prompt += " pinned"
+	}
+	return common.FilePanelTopDirectoryIconStyle.Render(prompt + " ")
+}
+
+// Returns the indexes of the names matching a query
+type nameMatcher func(names []string) []int
+
+func newNameMatcher(mode matchMode, query string) (nameMatcher, error) {
+	switch mode {
+	case globMatch:
+		if _, err := filepath.Match(query, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob pattern: %w", err)
+		}
+		return func(names []string) []int {
+			var matched []int
+			for i, name := range names {
+				// Paths, like the names in flatten mode, are matched by their
+				// last element unless the pattern has several
+				if !strings.Contains(query, string(filepath.Separator)) {
+					name = filepath.Base(name)
+				}
+				if ok, _ := filepath.Match(query, name); ok {
+					matched = append(matched, i)
+				}
+			}
+			return matched
+		}, nil
+	case regexMatch:
+		re, err := regexp.Compile(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		return func(names []string) []int {
+			var matched []int
+			for i, name := range names {
+				if re.MatchString(name) {
+					matched = append(matched, i)
+				}
+			}
+			return matched
+		}, nil
+	case exactMatch:
+		return func(names []string) []int {
+			var matched []int
+			for i, name := range names {
+				if strings.Contains(name, query) {
+					matched = append(matched, i)
+				}
+			}
+			return matched
+		}, nil
+	case fuzzyMatch:
+	}
+	return func(names []string) []int {
+		var matched []int
+		for _, match := range utils.FzfSearch(query, names) {
+			matched = append(matched, int(match.HayIndex))
+		}
+		slices.Sort(matched)
+		return matched
+	}, nil
+}
+
+// Returns whether a line of a file matches a query
+type lineMatcher func(line string) bool
+
+func newLineMatcher(mode matchMode, query string) (lineMatcher, error) {
+	if mode == regexMatch {
+		re, err := regexp.Compile(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		return re.MatchString, nil
+	}
+	return func(line string) bool {
+		return strings.Contains(line, query)
+	}, nil
+}
@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func Test_newNameMatcher(t *testing.T) {
+	names := []string{"main.go", "main_test.go", "README.md", "Makefile"}
+	testdata := []struct {
+		name     string
+		mode     matchMode
+		query    string
+		expected []int
+		err      bool
+	}{
+		{name: "Fuzzy", mode: fuzzyMatch, query: "mngo", expected: []int{0, 1}},
+		{name: "Glob", mode: globMatch, query: "*.go", expected: []int{0, 1}},
+		{name: "Glob exact name", mode: globMatch, query: "Makefile", expected: []int{3}},
+		{name: "Regex", mode: regexMatch, query: "^[A-Z]", expected: []int{2, 3}},
+		{name: "Exact", mode: exactMatch, query: "main", expected: []int{0, 1}},
+		{name: "Exact is case sensitive", mode: exactMatch, query: "readme", expected: nil},
+		{name: "Invalid glob", mode: globMatch, query: "[", err: true},
+		{name: "Invalid regex", mode: regexMatch, query: "(", err: true},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := newNameMatcher(tt.mode, tt.query)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, match(names))
+		})
+	}
+}
+
+func Test_matchModeNext(t *testing.T) {
+	assert.Equal(t, globMatch, fuzzyMatch.next(false))
+	assert.Equal(t, regexMatch, globMatch.next(false))
+	assert.Equal(t, exactMatch, regexMatch.next(false))
+	assert.Equal(t, fuzzyMatch, exactMatch.next(false))
+	assert.Equal(t, regexMatch, exactMatch.next(true))
+	assert.Equal(t, exactMatch, regexMatch.next(true))
+}
+
+func Test_filterElements(t *testing.T) {
+	var elements []element
+	for _, name := range []string{"app.log", "b.log", "c.txt", "log.go", filepath.Join("sub", "d.log")} {
+		elements = append(elements, element{name: name})
+	}
+	names := func(elements []element) []string {
+		var result []string
+		for _, e := range elements {
+			result = append(result, e.name)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"app.log", "b.log", filepath.Join("sub", "d.log")},
+		names(filterElements(elements, "*.log", globMatch)))
+	assert.Equal(t, []string{filepath.Join("sub", "d.log")},
+		names(filterElements(elements, filepath.Join("s*", "*"), globMatch)))
+	assert.Equal(t, []string{"log.go"}, names(filterElements(elements, "^log", regexMatch)))
+	assert.Equal(t, []string{"app.log", "b.log", "log.go", filepath.Join("sub", "d.log")},
+		names(filterElements(elements, "log", exactMatch)))
+	assert.Empty(t, filterElements(elements, "(", regexMatch))
+	assert.Equal(t, []string{"app.log", "b.log", "log.go", filepath.Join("sub", "d.log")},
+		names(filterElements(elements, "lg", fuzzyMatch)))
+}
+
+func TestModel_SearchBarModes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	m := defaultModelConfig(false, true, []string{dir})
+	panel := &m.fileModel.filePanels[0]
+	panel.element = returnDirElement(dir, false, panel.sortOptions.data, nil)
+
+	m.searchBarFocus()
+	m.focusOnSearchbarKey(common.Hotkeys.NextSearchMode[0])
+	assert.Equal(t, globMatch, panel.searchMode)
+	m.focusOnSearchbarKey(common.Hotkeys.ToggleSearchPin[0])
+	assert.True(t, panel.searchPinned)
+	panel.searchBar.SetValue("s*")
+	m.focusOnSearchbarKey(common.Hotkeys.ConfirmTyping[0])
+
+	// Pinned filter is kept in subdirectories
+	m.enterPanel()
+	assert.Equal(t, filepath.Join(dir, "sub"), panel.location)
+	assert.Equal(t, "s*", panel.searchBar.Value())
+
+	m.searchBarFocus()
+	m.focusOnSearchbarKey(common.Hotkeys.CancelTyping[0])
+	assert.Empty(t, panel.searchBar.Value())
+	assert.False(t, panel.searchPinned)
+	assert.Equal(t, globMatch, panel.searchMode, "search mode should be kept")
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+const (
+	// File panels are this many times wider than the parent column
+	parentColumnRatio = 3
+	// The parent column is hidden when it would be narrower
+	minParentColumnWidth = 10
+)
+
+// Width of the rendered parent column including its border, zero when the
+// column is not shown
+func (m *model) parentColumnFullWidth() int {
+	if !m.fileModel.millerColumns || m.fileModel.parentColumn.width == 0 {
+		return 0
+	}
+	return m.fileModel.parentColumn.width + 2
+}
+
+// Set the parent column width so that it takes a share of the width left by
+// the sidebar and file preview, next to the file panels
+func (m *model) setParentColumnWidth(width int) {
+	column := &m.fileModel.parentColumn
+	column.width = 0
+	if !m.fileModel.millerColumns {
+		return
+	}
+	panelCount := len(m.fileModel.filePanels)
+	// Borders of the sidebar, the file panels and the parent column
+	available := width - common.Config.SidebarWidth - m.fileModel.filePreview.width - (4 + panelCount*2)
+	if columnWidth := available / (parentColumnRatio*panelCount + 1); columnWidth >= minParentColumnWidth {
+		column.width = columnWidth
+	}
+}
+
+func (m *model) toggleMillerColumns() {
+	m.fileModel.millerColumns = !m.fileModel.millerColumns
+	m.fileModel.parentColumn = parentColumn{}
+	m.setFilePanelsSize(m.fullWidth)
+}
+
+// List the parent directory of the focused panel location in the parent
+// column. Like unfocused file panels, it is refreshed every 3 seconds
+func (m *model) getParentColumnItems() {
+	if m.parentColumnFullWidth() == 0 {
+		return
+	}
+	column := &m.fileModel.parentColumn
+	panel := m.fileModel.filePanels[m.filePanelFocusIndex]
+	location := filepath.Dir(panel.location)
+	if location == column.location && time.Since(column.lastTimeGetElement) < 3*time.Second &&
+		!m.updatedToggleDotFile {
+		return
+	}
+	column.location = location
+	column.lastTimeGetElement = time.Now()
+	// The root directory has no parent
+	if location == panel.location {
+		column.element = nil
+		return
+	}
+	column.element = filterIgnored(returnDirElement(location, m.toggleDotFile, panel.sortOptions.data, m.dirSizes), panel.ignoreMatcher())
+}
+
+func (m *model) parentColumnRender() string {
+	column := m.fileModel.parentColumn
+	panel := m.fileModel.filePanels[m.filePanelFocusIndex]
+	r := ""
+	if column.location != panel.location {
+		r += common.FilePanelTopDirectoryIconStyle.Render(" "+icon.Directory+icon.Space) +
+			common.FilePanelTopPathStyle.Render(common.TruncateTextBeginning(column.location, column.width-4, "..."))
+	}
+	r += "\n" + common.FilePanelDividerStyle(false).Render(strings.Repeat(common.Config.BorderTop, column.width))
+
+	// Keep the directory of the focused panel in the middle of the column
+	cursor := 0
+	for i, item := range column.element {
+		if item.location == panel.location {
+			cursor = i
+			break
+		}
+	}
+	height := m.mainPanelHeight - 2
+	start := max(0, min(cursor-height/2, len(column.element)-height))
+	for i := start; i < start+height && i < len(column.element); i++ {
+		item := column.element[i]
+		r += "\n " + common.PrettierName(item.name, column.width-3, item.directory, item.location == panel.location,
+			common.FilePanelBGColor, nil)
+	}
+	bottomBorder := strings.Repeat(common.Config.BorderBottom, column.width)
+	return common.FilePanelBorderStyle(m.mainPanelHeight, column.width, false, "", bottomBorder).
+		Render(lipgloss.NewStyle().MaxWidth(column.width).Render(r))
+}
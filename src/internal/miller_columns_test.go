@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func TestModel_MillerColumns(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"aaa", "bbb/inner", "ccc"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+	}
+	m := defaultModelConfig(false, true, []string{filepath.Join(dir, "ccc")})
+	TeaUpdate(&m, tea.WindowSizeMsg{Width: 2 * common.MinimumWidth, Height: 2 * common.MinimumHeight})
+	panelWidth := m.fileModel.width
+	assert.Zero(t, m.parentColumnFullWidth())
+
+	m.mainKey(common.Hotkeys.ToggleMillerColumns[0], nil)
+	require.True(t, m.fileModel.millerColumns)
+	require.Positive(t, m.parentColumnFullWidth())
+	assert.Less(t, m.fileModel.width, panelWidth)
+	assert.Equal(t, panelWidth+2, m.fileModel.width+2+m.parentColumnFullWidth(), "The parent column takes its width from the file panel")
+
+	m.getFilePanelItems()
+	column := m.fileModel.parentColumn
+	assert.Equal(t, dir, column.location)
+	require.Len(t, column.element, 3)
+
+	// Going to the parent directory keeps the cursor on the directory that was left
+	panel := &m.fileModel.filePanels[0]
+	m.parentDirectory()
+	assert.Equal(t, dir, panel.location)
+	assert.Equal(t, filepath.Join(dir, "ccc"), panel.element[panel.cursor].location)
+
+	// The parent column is hidden when the window is too narrow
+	TeaUpdate(&m, tea.WindowSizeMsg{Width: common.MinimumWidth, Height: 2 * common.MinimumHeight})
+	assert.Zero(t, m.parentColumnFullWidth())
+
+	m.mainKey(common.Hotkeys.ToggleMillerColumns[0], nil)
+	assert.False(t, m.fileModel.millerColumns)
+}
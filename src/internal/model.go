@@ -13,6 +13,11 @@ import (
 	"time"
 
 	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/dirsort"
+	"github.com/yorukot/superfile/src/internal/marks"
+	"github.com/yorukot/superfile/src/internal/tags"
+	"github.com/yorukot/superfile/src/internal/ui/sidebar"
 	"github.com/yorukot/superfile/src/internal/utils"
 
 	"github.com/barasher/go-exiftool"
@@ -39,11 +44,32 @@ var progressBarLastRenderTime = time.Now()                      //nolint: gochec
 // Either way type 'model' is not exported, so there is not way main package can
 // be aware of it, and use it directly
 func InitialModel(firstFilePanelDirs []string, firstUseCheck, hasTrashCheck bool) tea.Model {
+	// Must be checked before initialConfig() replaces the empty path with default directory
+	noPathArgs := len(firstFilePanelDirs) == 1 && firstFilePanelDirs[0] == ""
 	toggleDotFile, toggleFooter := initialConfig(firstFilePanelDirs)
 	firstUse = firstUseCheck
 	hasTrash = hasTrashCheck
 	batCmd = checkBatCmd()
-	return defaultModelConfig(toggleDotFile, toggleFooter, firstFilePanelDirs)
+	m := defaultModelConfig(toggleDotFile, toggleFooter, firstFilePanelDirs)
+	m.dirSizes = dirsize.New(dirSizeWorkers, notifyDirSizes)
+	m.dirSorts = dirsort.Load(variable.DirectorySortFile)
+	m.dirWatcher = newDirWatcher()
+	m.gitStatuses = newGitStatusCache()
+	m.fileTags = tags.Load(variable.TagsFile)
+	// The sidebar lists the tagged files
+	m.sidebarModel = sidebar.New(m.fileTags)
+	// The initial panels are sorted with the settings of their location
+	for i := range m.fileModel.filePanels {
+		m.applyDirectorySort(&m.fileModel.filePanels[i])
+	}
+	m.frecency = loadFrecencyDatabase()
+	m.marks = marks.Load(variable.MarksFile)
+	if noPathArgs && sessionEnabled() {
+		if err := m.restoreSession(variable.SessionFile); err != nil {
+			slog.Error("Error while restoring session", "session", variable.SessionFile, "error", err)
+		}
+	}
+	return m
 }
 
 // Init function to be called by Bubble tea framework, sets windows title,
@@ -73,6 +99,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		msgStr := msg.String()
 		if msgStr == "wheel up" || msgStr == "wheel down" {
 			wheelMainAction(msgStr, &m)
+		} else if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			clickMainAction(msg, &m)
 		} else {
 			slog.Debug("Mouse event of type that is not handled", "msg", msgStr)
 		}
@@ -91,6 +119,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	m.getFilePanelItems()
+	m.updateItemsSizes()
 	if !m.firstLoadingComplete {
 		m.firstLoadingComplete = true
 	}
@@ -104,6 +133,20 @@ func (m *model) handleChannelMessage(msg channelMessage) {
 		m.warnModal = msg.warnModal
 	case sendMetadata:
 		m.fileMetaData.metaData = msg.metadata
+	case sendFindResults:
+		m.handleFindResults(msg.findResults)
+	case sendFuzzySearchResults:
+		m.handleFuzzySearchResults(msg.fuzzySearchResults)
+	case sendFlatElements:
+		m.handleFlatElements(msg.flatElements)
+	case sendDirSizes:
+		m.handleDirSizes(m.dirSizes.TakeComputed())
+	case sendDirChanges:
+		m.handleDirChanges(m.dirWatcher.TakeChanges())
+	case sendGitStatus:
+		// Nothing to update, the panels are rendered with the new status
+	case sendGitDiff:
+		m.handleGitDiff(msg.gitDiff)
 	case sendProcess:
 		if !arrayContains(m.processBarModel.processList, msg.messageID) {
 			m.processBarModel.processList = append(m.processBarModel.processList, msg.messageID)
@@ -128,6 +171,12 @@ func (m *model) handleWindowResize(msg tea.WindowSizeMsg) {
 	m.setFilePanelsSize(msg.Width)
 	m.setHeightValues(msg.Height)
 	m.setHelpMenuSize()
+	m.historyModal.SetSize(m.pickerModalSize())
+	m.jumpModal.SetSize(m.pickerModalSize())
+	m.marksModal.picker.SetSize(m.pickerModalSize())
+	m.tagModal.picker.SetSize(m.pickerModalSize())
+	m.tagFilterModal.SetSize(m.pickerModalSize())
+	m.selectByModal.picker.SetSize(m.pickerModalSize())
 
 	if m.fileModel.maxFilePanel >= 10 {
 		m.fileModel.maxFilePanel = 10
@@ -145,9 +194,10 @@ func (m *model) setFilePreviewWidth(width int) {
 
 // Proper set panels size. Assure that panels do not overlap
 func (m *model) setFilePanelsSize(width int) {
+	m.setParentColumnWidth(width)
 	// set each file panel size and max file panel amount
-	m.fileModel.width = (width - common.Config.SidebarWidth - m.fileModel.filePreview.width - (4 + (len(m.fileModel.filePanels)-1)*2)) / len(m.fileModel.filePanels)
-	m.fileModel.maxFilePanel = (width - common.Config.SidebarWidth - m.fileModel.filePreview.width) / 20
+	m.fileModel.width = (width - common.Config.SidebarWidth - m.fileModel.filePreview.width - m.parentColumnFullWidth() - (4 + (len(m.fileModel.filePanels)-1)*2)) / len(m.fileModel.filePanels)
+	m.fileModel.maxFilePanel = (width - common.Config.SidebarWidth - m.fileModel.filePreview.width - m.parentColumnFullWidth()) / 20
 	for i := range m.fileModel.filePanels {
 		m.fileModel.filePanels[i].searchBar.Width = m.fileModel.width - 4
 	}
@@ -189,6 +239,16 @@ func (m *model) setHelpMenuSize() {
 	}
 }
 
+// Width and height of picker modals excluding border
+func (m *model) pickerModalSize() (int, int) {
+	return min(m.fullWidth-4, common.ModalWidth), min(m.fullHeight-4, 20)
+}
+
+func (m *model) pickerModalOverlayPosition() (int, int) {
+	width, height := m.pickerModalSize()
+	return m.fullWidth/2 - width/2 - 1, m.fullHeight/2 - height/2 - 1
+}
+
 // Identify the current state of the application m and properly handle the
 // msg keybind pressed
 func (m *model) handleKeyInput(msg tea.KeyMsg, cmd tea.Cmd) tea.Cmd {
@@ -222,11 +282,31 @@ func (m *model) handleKeyInput(msg tea.KeyMsg, cmd tea.Cmd) tea.Cmd {
 
 	case m.warnModal.open:
 		m.warnModalOpenKey(msg.String())
+	case m.historyModal.IsOpen():
+		m.historyModalKey(msg.String())
+	case m.jumpModal.IsOpen():
+		m.jumpModalKey(msg.String())
+	case m.marksModal.renaming != "":
+		m.marksRenameKey(msg.String())
+	case m.marksModal.picker.IsOpen():
+		m.marksModalKey(msg.String())
+	case m.pendingMark != noPendingMark:
+		m.pendingMarkKey(msg.String())
+	case m.tagModal.picker.IsOpen():
+		m.tagModalKey(msg.String())
+	case m.tagFilterModal.IsOpen():
+		m.tagFilterModalKey(msg.String())
+	case m.selectByModal.typing:
+		m.selectByValueKey(msg.String())
+	case m.selectByModal.picker.IsOpen():
+		m.selectByModalKey(msg.String())
 	// If renaming a object
 	case m.fileModel.renaming:
 		m.renamingKey(msg.String())
 	case m.sidebarModel.IsRenaming():
 		m.sidebarRenamingKey(msg.String())
+	case m.fileModel.filePanels[m.filePanelFocusIndex].finder.textInput.Focused():
+		m.findBarKey(msg.String())
 	// If search bar is open
 	case m.fileModel.filePanels[m.filePanelFocusIndex].searchBar.Focused():
 		m.focusOnSearchbarKey(msg.String())
@@ -273,8 +353,18 @@ func (m *model) updateFilePanelsState(msg tea.Msg, cmd *tea.Cmd) {
 		focusPanel.rename, *cmd = focusPanel.rename.Update(msg)
 	case focusPanel.searchBar.Focused():
 		focusPanel.searchBar, *cmd = focusPanel.searchBar.Update(msg)
+	case focusPanel.finder.textInput.Focused():
+		focusPanel.finder.textInput, *cmd = focusPanel.finder.textInput.Update(msg)
 	case m.typingModal.open:
 		m.typingModal.textInput, *cmd = m.typingModal.textInput.Update(msg)
+	case m.jumpModal.IsOpen():
+		*cmd = m.jumpModal.UpdateState(msg)
+	case m.marksModal.renaming != "":
+		m.marksModal.textInput, *cmd = m.marksModal.textInput.Update(msg)
+	case m.tagModal.picker.IsOpen():
+		*cmd = m.tagModal.picker.UpdateState(msg)
+	case m.selectByModal.typing:
+		m.selectByModal.textInput, *cmd = m.selectByModal.textInput.Update(msg)
 	case m.promptModal.IsOpen():
 		// *cmd is a non-name, and cannot be used on left of :=
 		var action common.ModelAction
@@ -290,6 +380,13 @@ func (m *model) updateFilePanelsState(msg tea.Msg, cmd *tea.Cmd) {
 	if focusPanel.cursor < 0 {
 		focusPanel.cursor = 0
 	}
+	// The range follows the cursor, whatever moved it
+	focusPanel.updateRangeSelect()
+	// The diff is only previewed until the cursor leaves its item
+	if diff := m.fileModel.filePreview.gitDiff; diff != nil && (focusPanel.cursor >= len(focusPanel.element) ||
+		focusPanel.element[focusPanel.cursor].location != diff.location) {
+		m.fileModel.filePreview.gitDiff = nil
+	}
 }
 
 // Apply the Action and notify the promptModal
@@ -310,7 +407,7 @@ func (m *model) applyPromptModalAction(action common.ModelAction) {
 		actionErr = m.splitPanel()
 		successMsg = "Panel successfully split"
 	case common.CDCurrentPanelAction:
-		actionErr = m.updateCurrentFilePanelDir(action.Location)
+		actionErr = m.cdCurrentFilePanel(action.Location, action.Keywords)
 		successMsg = "Panel directory changed"
 	case common.OpenPanelAction:
 		actionErr = m.createNewFilePanel(action.Location)
@@ -359,7 +456,9 @@ func (m *model) updateCurrentFilePanelDir(dir string) error {
 		return fmt.Errorf("%s is not a directory", newPath)
 	}
 
-	m.fileModel.filePanels[m.filePanelFocusIndex].location = newPath
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.changeLocation(newPath)
+	m.handleLocationChange(panel)
 	return nil
 }
 
@@ -444,10 +543,59 @@ func (m model) View() string {
 		return stringfunction.PlaceOverlay(overlayX, overlayY, promptModal, finalRender)
 	}
 
+	if m.historyModal.IsOpen() {
+		historyModal := m.historyModal.Render()
+		overlayX, overlayY := m.pickerModalOverlayPosition()
+		return stringfunction.PlaceOverlay(overlayX, overlayY, historyModal, finalRender)
+	}
+
+	if m.jumpModal.IsOpen() {
+		jumpModal := m.jumpModal.Render()
+		overlayX, overlayY := m.pickerModalOverlayPosition()
+		return stringfunction.PlaceOverlay(overlayX, overlayY, jumpModal, finalRender)
+	}
+
+	if m.marksModal.renaming != "" {
+		marksRenameModal := m.marksRenameModalRender()
+		overlayX := m.fullWidth/2 - common.ModalWidth/2
+		overlayY := m.fullHeight/2 - common.ModalHeight/2
+		return stringfunction.PlaceOverlay(overlayX, overlayY, marksRenameModal, finalRender)
+	}
+
+	if m.marksModal.picker.IsOpen() {
+		marksModal := m.marksModal.picker.Render()
+		overlayX, overlayY := m.pickerModalOverlayPosition()
+		return stringfunction.PlaceOverlay(overlayX, overlayY, marksModal, finalRender)
+	}
+
+	if m.tagModal.picker.IsOpen() {
+		tagModal := m.tagModal.picker.Render()
+		overlayX, overlayY := m.pickerModalOverlayPosition()
+		return stringfunction.PlaceOverlay(overlayX, overlayY, tagModal, finalRender)
+	}
+
+	if m.tagFilterModal.IsOpen() {
+		tagFilterModal := m.tagFilterModal.Render()
+		overlayX, overlayY := m.pickerModalOverlayPosition()
+		return stringfunction.PlaceOverlay(overlayX, overlayY, tagFilterModal, finalRender)
+	}
+
+	if m.selectByModal.typing {
+		selectByValueModal := m.selectByValueModalRender()
+		overlayX := m.fullWidth/2 - common.ModalWidth/2
+		overlayY := m.fullHeight/2 - common.ModalHeight/2
+		return stringfunction.PlaceOverlay(overlayX, overlayY, selectByValueModal, finalRender)
+	}
+
+	if m.selectByModal.picker.IsOpen() {
+		selectByModal := m.selectByModal.picker.Render()
+		overlayX, overlayY := m.pickerModalOverlayPosition()
+		return stringfunction.PlaceOverlay(overlayX, overlayY, selectByModal, finalRender)
+	}
+
 	if panel.sortOptions.open {
 		sortOptions := m.sortOptionsRender()
-		overlayX := m.fullWidth/2 - panel.sortOptions.width/2
-		overlayY := m.fullHeight/2 - panel.sortOptions.height/2
+		overlayX, overlayY := m.sortOptionsOverlayPosition()
 		return stringfunction.PlaceOverlay(overlayX, overlayY, sortOptions, finalRender)
 	}
 
@@ -501,11 +649,21 @@ func listenForChannelMessage(msg chan channelMessage) tea.Cmd {
 }
 
 // Render and update file panel items. Check for changes and updates in files and
-// folders in the current directory.
+// folders in the current directory. Panels whose directories are watched are
+// only read again when the listing changes, their changes come in through
+// handleDirChanges. The others, like the ones on network file systems, are
+// polled
 func (m *model) getFilePanelItems() {
+	m.watchPanelLocations()
 	focusPanel := m.fileModel.filePanels[m.filePanelFocusIndex]
 	for i, filePanel := range m.fileModel.filePanels {
-		var fileElement []element
+		// Find results are filled in by handleFindResults
+		if filePanel.finder.active {
+			continue
+		}
+		if m.updateWatchedPanel(&m.fileModel.filePanels[i]) {
+			continue
+		}
 		nowTime := time.Now()
 		// Check last time each element was updated, if less then 3 seconds ignore
 		if filePanel.focusType == noneFocus && nowTime.Sub(filePanel.lastTimeGetElement) < 3*time.Second {
@@ -519,7 +677,9 @@ func (m *model) getFilePanelItems() {
 		focusPanelReRender := false
 
 		if len(focusPanel.element) > 0 {
-			if filepath.Dir(focusPanel.element[0].location) != focusPanel.location {
+			// Items can be below the location in flatten mode
+			relPath, err := filepath.Rel(focusPanel.location, focusPanel.element[0].location)
+			if err != nil || strings.HasPrefix(relPath, "..") {
 				focusPanelReRender = true
 			}
 		} else {
@@ -532,21 +692,57 @@ func (m *model) getFilePanelItems() {
 			continue
 		}
 
-		// Get file names based on search bar filter
-		if filePanel.searchBar.Value() != "" {
-			fileElement = returnDirElementBySearchString(filePanel.location, m.toggleDotFile, filePanel.searchBar.Value())
-		} else {
-			fileElement = returnDirElement(filePanel.location, m.toggleDotFile, filePanel.sortOptions.data)
+		// Polled directories may have changed since the last read
+		m.fileModel.filePanels[i].searchedElements = nil
+		if time.Since(filePanel.flatWalk.doneTime) >= flatWalkPollInterval {
+			m.fileModel.filePanels[i].flatWalk.stale = true
 		}
-		// Update file panel list
-		filePanel.element = fileElement
-		m.fileModel.filePanels[i].element = fileElement
-		m.fileModel.filePanels[i].lastTimeGetElement = nowTime
+		m.readPanelElements(&m.fileModel.filePanels[i])
 	}
 
+	m.getParentColumnItems()
 	m.updatedToggleDotFile = false
 }
 
+// Read the items of the panel, filtered by its search bar. Fuzzy matches are
+// listed by handleFuzzySearchResults
+func (m *model) readPanelElements(panel *filePanel) {
+	panel.lastTimeGetElement = time.Now()
+	if panel.searchBar.Value() == "" {
+		panel.stopFuzzySearch()
+		panel.searchedElements = nil
+		panel.element = m.dirElements(panel)
+		return
+	}
+	listing := panel.listingState(m.toggleDotFile)
+	listing.search, listing.searchMode = "", 0
+	if panel.searchedElements == nil || panel.searchedListing != listing {
+		panel.searchedElements = m.dirElements(panel)
+		panel.searchedListing = listing
+	}
+	if panel.searchMode == fuzzyMatch {
+		m.startFuzzySearch(panel, panel.searchedElements)
+		return
+	}
+	panel.stopFuzzySearch()
+	panel.element = filterElements(panel.searchedElements, panel.searchBar.Value(), panel.searchMode)
+}
+
+// Read the directories again on the next read of the listing, instead of
+// reusing the items kept for the search bar or the last flatten walk
+func (panel *filePanel) markListingStale() {
+	panel.searchedElements = nil
+	panel.flatWalk.stale = true
+}
+
+// Clear the items of the panel, so that getFilePanelItems reads them again
+// right away
+func (panel *filePanel) resetElements() {
+	panel.element = nil
+	panel.markListingStale()
+	panel.lastTimeGetElement = time.Time{}
+}
+
 // Close superfile application. Cd into the current dir if CdOnQuit on and save
 // the path in state direcotory
 func (m *model) quitSuperfile() {
@@ -566,6 +762,15 @@ func (m *model) quitSuperfile() {
 			slog.Error("Error during writing lastdir file", "error", err)
 		}
 	}
+	m.dirWatcher.Close()
+	if err := m.frecency.Save(); err != nil {
+		slog.Error("Error while saving frecency database", "error", err)
+	}
+	if sessionEnabled() {
+		if err := m.saveSession(variable.SessionFile); err != nil {
+			slog.Error("Error while saving session", "session", variable.SessionFile, "error", err)
+		}
+	}
 	slog.Debug("Quitting superfile", "current dir", currentDir)
 }
 
@@ -43,21 +43,23 @@ func (m *model) filePanelRender() string {
 		}
 		m.fileModel.filePanels[i] = filePanel
 
-		f[i] += common.FilePanelTopDirectoryIconStyle.Render(" "+icon.Directory+icon.Space) + common.FilePanelTopPathStyle.Render(common.TruncateTextBeginning(filePanel.location, m.fileModel.width-4, "...")) + "\n"
-		var filePanelWidth int
+		gitStatus := m.gitStatuses.Status(filePanel.location)
+		pathWidth := m.fileModel.width - 4
+		branch := gitBranchString(gitStatus)
+		if branch != "" {
+			branch = common.TruncateText(branch, pathWidth/2, "...")
+			pathWidth -= lipgloss.Width(branch) + 1
+			branch = common.FilePanelStyle.Render(" ") + common.GitBranchStyle.Render(branch)
+		}
+		tagFilter := ""
+		if filePanel.tagFilter != "" {
+			tagFilter = common.TruncateText(icon.Tag+icon.Space+filePanel.tagFilter, pathWidth/2, "...")
+			pathWidth -= lipgloss.Width(tagFilter) + 1
+			tagFilter = common.FilePanelStyle.Render(" ") + common.TagStyle.Render(tagFilter)
+		}
+		f[i] += common.FilePanelTopDirectoryIconStyle.Render(" "+icon.Directory+icon.Space) + common.FilePanelTopPathStyle.Render(common.TruncateTextBeginning(filePanel.location, pathWidth, "...")) + branch + tagFilter + "\n"
 		footerBorderWidth := m.fileModel.width + 15
-
-		// Todo : Move this to a utility function and clarify the calculation via comments
-		// Maybe even write unit tests
-		if (m.fullWidth-common.Config.SidebarWidth-(4+(len(m.fileModel.filePanels)-1)*2))%len(m.fileModel.filePanels) != 0 && i == len(m.fileModel.filePanels)-1 {
-			if m.fileModel.filePreview.open {
-				filePanelWidth = m.fileModel.width
-			} else {
-				filePanelWidth = (m.fileModel.width + (m.fullWidth-common.Config.SidebarWidth-(4+(len(m.fileModel.filePanels)-1)*2))%len(m.fileModel.filePanels))
-			}
-		} else {
-			filePanelWidth = m.fileModel.width
-		}
+		filePanelWidth := m.filePanelWidth(i)
 
 		var sortDirectionString string
 		if filePanel.sortOptions.data.reversed {
@@ -77,16 +79,33 @@ func (m *model) filePanelRender() string {
 		if filePanelWidth < 23 {
 			sortTypeString = sortDirectionString
 		} else {
-			if filePanel.sortOptions.data.options[filePanel.sortOptions.data.selected] == "Date Modified" {
+			switch filePanel.sortOptions.data.selected {
+			case common.SortByDateModified:
 				sortTypeString = sortDirectionString + icon.Space + "Date"
-			} else {
+			case common.SortByDateCreated:
+				sortTypeString = sortDirectionString + icon.Space + "Created"
+			case common.SortByDateAccessed:
+				sortTypeString = sortDirectionString + icon.Space + "Accessed"
+			default:
 				sortTypeString = sortDirectionString + icon.Space + filePanel.sortOptions.data.options[filePanel.sortOptions.data.selected]
 			}
 		}
 
 		panelModeString := ""
 		if filePanelWidth < 23 {
-			if filePanel.panelMode == browserMode {
+			if filePanel.panelMode == browserMode && filePanel.flatten {
+				if common.Config.Nerdfont {
+					panelModeString = icon.Flatten
+				} else {
+					panelModeString = "F"
+				}
+			} else if filePanel.panelMode == browserMode && filePanel.treeView {
+				if common.Config.Nerdfont {
+					panelModeString = icon.Tree
+				} else {
+					panelModeString = "T"
+				}
+			} else if filePanel.panelMode == browserMode {
 				if common.Config.Nerdfont {
 					panelModeString = icon.Browser
 				} else {
@@ -98,22 +117,62 @@ func (m *model) filePanelRender() string {
 				} else {
 					panelModeString = "S"
 				}
+				panelModeString += strconv.Itoa(len(filePanel.selected))
 			}
 		} else {
-			if filePanel.panelMode == browserMode {
+			if filePanel.panelMode == browserMode && filePanel.flatten {
+				panelModeString = icon.Flatten + icon.Space + "Flatten"
+				// Only the first items are listed
+				if filePanel.flatWalk.truncated {
+					panelModeString += " " + strconv.Itoa(maxFlattenItems) + "+"
+				}
+			} else if filePanel.panelMode == browserMode && filePanel.treeView {
+				panelModeString = icon.Tree + icon.Space + "Tree"
+			} else if filePanel.panelMode == browserMode {
 				panelModeString = icon.Browser + icon.Space + "Browser"
+			} else if filePanel.panelMode == selectMode && filePanel.rangeAnchor != "" {
+				panelModeString = icon.Select + icon.Space + "Range " + strconv.Itoa(len(filePanel.selected))
 			} else if filePanel.panelMode == selectMode {
-				panelModeString = icon.Select + icon.Space + "Select"
+				panelModeString = icon.Select + icon.Space + "Select " + strconv.Itoa(len(filePanel.selected))
+			}
+		}
+		footerInfo := sortTypeString + common.BottomMiddleBorderSplit + panelModeString
+		// Total size of the selected items
+		if filePanel.panelMode == selectMode && len(filePanel.selected) > 0 && filePanelWidth >= 23 {
+			footerInfo += common.BottomMiddleBorderSplit + formatItemsSize(filePanel.selectedSize.size, filePanel.selectedSize.complete)
+		}
+		// Shown while ignored items are hidden
+		if filePanel.hideIgnored {
+			switch {
+			case filePanelWidth >= 23:
+				footerInfo += common.BottomMiddleBorderSplit + icon.HideIgnored + icon.Space + "Ignoring"
+			case common.Config.Nerdfont:
+				footerInfo += common.BottomMiddleBorderSplit + icon.HideIgnored
+			default:
+				footerInfo += common.BottomMiddleBorderSplit + "I"
 			}
 		}
 
 		f[i] += common.FilePanelDividerStyle(filePanel.focusType != noneFocus).Render(strings.Repeat(common.Config.BorderTop, filePanelWidth)) + "\n"
-		f[i] += " " + filePanel.searchBar.View() + "\n"
+		if filePanel.finder.active {
+			filePanel.finder.textInput.Width = m.fileModel.width - 5 - len(filePanel.finder.mode.String())
+			f[i] += " " + filePanel.finder.textInput.View() + "\n"
+		} else {
+			filePanel.searchBar.Prompt = searchPrompt(filePanel.searchMode, filePanel.searchPinned)
+			filePanel.searchBar.Width = m.fileModel.width - 4 - (lipgloss.Width(filePanel.searchBar.Prompt) - lipgloss.Width(icon.Search+icon.Space))
+			f[i] += " " + filePanel.searchBar.View() + "\n"
+		}
 		if len(filePanel.element) == 0 {
-			f[i] += common.FilePanelStyle.Render(" " + icon.Error + "  No such file or directory")
-			bottomBorder := common.GenerateFooterBorder(fmt.Sprintf("%s%s%s%s%s", sortTypeString, common.BottomMiddleBorderSplit, panelModeString, common.BottomMiddleBorderSplit, "0/0"), footerBorderWidth)
-			f[i] = common.FilePanelBorderStyle(m.mainPanelHeight, filePanelWidth, filePanel.focusType != noneFocus, bottomBorder).Render(f[i])
+			f[i] += common.FilePanelStyle.Render(" " + filePanel.emptyMessage())
+			bottomBorder := common.GenerateFooterBorder(fmt.Sprintf("%s%s%s", footerInfo, common.BottomMiddleBorderSplit, "0/0"), footerBorderWidth)
+			f[i] = common.FilePanelBorderStyle(m.mainPanelHeight, filePanelWidth, filePanel.focusType != noneFocus, filePanel.tabBorder(filePanelWidth), bottomBorder).Render(f[i])
 		} else {
+			var columns []detailColumn
+			if names := filePanel.detailColumnNames(); len(names) > 0 {
+				lastRow := min(filePanel.render+panelElementHeight(m.mainPanelHeight), len(filePanel.element))
+				columns = detailColumns(names, filePanel.element[filePanel.render:lastRow], m.fileModel.width-5, m.dirSizes)
+			}
+			columnsWidth := detailColumnsWidth(columns)
 			for h := filePanel.render; h < filePanel.render+panelElementHeight(m.mainPanelHeight) && h < len(filePanel.element); h++ {
 				endl := "\n"
 				if h == filePanel.render+panelElementHeight(m.mainPanelHeight)-1 || h == len(filePanel.element)-1 {
@@ -121,7 +180,7 @@ func (m *model) filePanelRender() string {
 				}
 				cursor := " "
 				// Check if the cursor needs to be displayed, if the user is using the search bar, the cursor is not displayed
-				if h == filePanel.cursor && !filePanel.searchBar.Focused() {
+				if h == filePanel.cursor && !filePanel.searchBar.Focused() && !filePanel.finder.textInput.Focused() {
 					cursor = icon.Cursor
 				}
 				isItemSelected := arrayContains(filePanel.selected, filePanel.element[h].location)
@@ -129,24 +188,61 @@ func (m *model) filePanelRender() string {
 					f[i] += filePanel.rename.View() + endl
 				} else {
 					_, err := os.ReadDir(filePanel.element[h].location)
-					f[i] += common.FilePanelCursorStyle.Render(cursor+" ") + common.PrettierName(filePanel.element[h].name, m.fileModel.width-5, filePanel.element[h].directory || (err == nil), isItemSelected, common.FilePanelBGColor) + endl
+					treePrefix := filePanel.element[h].treePrefix
+					nameWidth := m.fileModel.width - 5 - lipgloss.Width(treePrefix) - columnsWidth
+					// Git marker and tag labels, shown after the name
+					suffix := ""
+					if gitStatus != nil {
+						suffix += common.FilePanelStyle.Render(" ") + gitStateMarker(gitStatus.State(filePanel.element[h].location))
+					}
+					if labels := tagLabels(m.fileTags.Tags(filePanel.element[h].location), nameWidth/3); labels != "" {
+						suffix += common.FilePanelStyle.Render(" ") + labels
+					}
+					name := common.PrettierName(filePanel.element[h].name, nameWidth-lipgloss.Width(suffix), filePanel.element[h].directory || (err == nil), isItemSelected, common.FilePanelBGColor, filePanel.element[h].matchPositions) + suffix
+					if len(columns) > 0 {
+						// Pad names so that the columns line up, the icon takes two cells
+						name += common.FilePanelStyle.Render(strings.Repeat(" ", max(0, nameWidth+2-lipgloss.Width(name))))
+						name += renderDetailRow(columns, h-filePanel.render)
+					}
+					f[i] += common.FilePanelCursorStyle.Render(cursor+" ") +
+						common.FilePanelDividerStyle(filePanel.focusType != noneFocus).Render(treePrefix) + name + endl
 				}
 			}
 			cursorPosition := strconv.Itoa(filePanel.cursor + 1)
 			totalElement := strconv.Itoa(len(filePanel.element))
+			if filePanel.finder.running {
+				totalElement += "+"
+			}
 
-			bottomBorder := common.GenerateFooterBorder(fmt.Sprintf("%s%s%s%s%s/%s", sortTypeString, common.BottomMiddleBorderSplit, panelModeString, common.BottomMiddleBorderSplit, cursorPosition, totalElement), footerBorderWidth)
-			f[i] = common.FilePanelBorderStyle(m.mainPanelHeight, filePanelWidth, filePanel.focusType != noneFocus, bottomBorder).Render(f[i])
+			bottomBorder := common.GenerateFooterBorder(fmt.Sprintf("%s%s%s/%s", footerInfo, common.BottomMiddleBorderSplit, cursorPosition, totalElement), footerBorderWidth)
+			f[i] = common.FilePanelBorderStyle(m.mainPanelHeight, filePanelWidth, filePanel.focusType != noneFocus, filePanel.tabBorder(filePanelWidth), bottomBorder).Render(f[i])
 		}
 	}
 
 	// file panel render together
 	filePanelRender := ""
-	for _, f := range f {
+	for i, f := range f {
+		if i == m.filePanelFocusIndex && m.parentColumnFullWidth() > 0 {
+			filePanelRender = lipgloss.JoinHorizontal(lipgloss.Top, filePanelRender, m.parentColumnRender())
+		}
 		filePanelRender = lipgloss.JoinHorizontal(lipgloss.Top, filePanelRender, f)
 	}
 	return filePanelRender
 }
+
+// Width of the file panel at panelIndex, excluding its border. The last panel takes
+// up the remaining width left by the integer division in fileModel.width calculation,
+// unless the file preview is open.
+// Todo : Clarify the calculation via comments. Maybe even write unit tests
+func (m *model) filePanelWidth(panelIndex int) int {
+	panelCount := len(m.fileModel.filePanels)
+	remainder := (m.fullWidth - common.Config.SidebarWidth - m.parentColumnFullWidth() - (4 + (panelCount-1)*2)) % panelCount
+	if remainder != 0 && panelIndex == panelCount-1 && !m.fileModel.filePreview.open {
+		return m.fileModel.width + remainder
+	}
+	return m.fileModel.width
+}
+
 func (m *model) processBarRender() string {
 	if !m.processBarModel.isValid(m.footerHeight) {
 		slog.Error("processBar in invalid state", "render", m.processBarModel.render,
@@ -324,15 +420,27 @@ func (m *model) metadataRender() string {
 func (m *model) clipboardRender() string {
 	// render
 	clipboardRender := ""
+	// Count and total size of the items, shown in the bottom border
+	summary := ""
 	if len(m.copyItems.items) == 0 {
 		clipboardRender += "\n " + icon.Error + "  No content in clipboard"
 	} else {
-		for i := 0; i < len(m.copyItems.items) && i < m.footerHeight; i++ {
+		summary = strconv.Itoa(len(m.copyItems.items)) + " items"
+		if len(m.copyItems.items) == 1 {
+			summary = "1 item"
+		}
+		summary += common.BottomMiddleBorderSplit + formatItemsSize(m.clipboardSize.size, m.clipboardSize.complete)
+		itemLines := m.footerHeight
+		if warning := m.clipboardSpace.warning; warning != "" {
+			clipboardRender += common.ProcessErrorStyle.Render(common.TruncateText(icon.Warn+icon.Space+warning, utils.FooterWidth(m.fullWidth)-3, "..."))
+			itemLines--
+		}
+		for i := 0; i < len(m.copyItems.items) && i < itemLines; i++ {
 			// Newline separator before all entries except first
-			if i != 0 {
+			if i != 0 || clipboardRender != "" {
 				clipboardRender += "\n"
 			}
-			if i == m.footerHeight-1 && i != len(m.copyItems.items)-1 {
+			if i == itemLines-1 && i != len(m.copyItems.items)-1 {
 				// Last Entry we can render, but there are more that one left
 				clipboardRender += strconv.Itoa(len(m.copyItems.items)-i) + " item left...."
 			} else {
@@ -353,7 +461,11 @@ func (m *model) clipboardRender() string {
 	} else {
 		bottomWidth = utils.FooterWidth(m.fullWidth)
 	}
-	clipboardRender = common.ClipboardBorder(m.footerHeight, bottomWidth, common.Config.BorderBottom).Render(clipboardRender)
+	bottomBorder := common.Config.BorderBottom
+	if summary != "" {
+		bottomBorder = common.GenerateFooterBorder(summary, bottomWidth-3)
+	}
+	clipboardRender = common.ClipboardBorder(m.footerHeight, bottomWidth, bottomBorder).Render(clipboardRender)
 
 	return clipboardRender
 }
@@ -422,6 +534,36 @@ func (m *model) typineModalRender() string {
 	return common.ModalBorderStyle(common.ModalHeight, common.ModalWidth).Render(fileLocation + "\n" + m.typingModal.textInput.View() + "\n\n" + tip)
 }
 
+func (m *model) marksRenameModalRender() string {
+	title := common.ModalTitleStyle.Render(" Rename mark "+m.marksModal.renaming) + "\n"
+
+	confirm := common.ModalConfirm.Render(" (" + common.Hotkeys.ConfirmTyping[0] + ") Rename ")
+	cancel := common.ModalCancel.Render(" (" + common.Hotkeys.CancelTyping[0] + ") Cancel ")
+
+	tip := confirm +
+		lipgloss.NewStyle().Background(common.ModalBGColor).Render("           ") +
+		cancel
+
+	return common.ModalBorderStyle(common.ModalHeight, common.ModalWidth).Render(title + "\n" + m.marksModal.textInput.View() + "\n\n" + tip)
+}
+
+func (m *model) selectByValueModalRender() string {
+	title := common.ModalTitleStyle.Render(" Select by: "+selectCriteria[m.selectByModal.criterion]) + "\n"
+	errorLine := ""
+	if m.selectByModal.err != "" {
+		errorLine = common.PromptFailureStyle.Render(" " + icon.Error + icon.Space + m.selectByModal.err)
+	}
+
+	confirm := common.ModalConfirm.Render(" (" + common.Hotkeys.ConfirmTyping[0] + ") Select ")
+	cancel := common.ModalCancel.Render(" (" + common.Hotkeys.CancelTyping[0] + ") Cancel ")
+
+	tip := confirm +
+		lipgloss.NewStyle().Background(common.ModalBGColor).Render("           ") +
+		cancel
+
+	return common.ModalBorderStyle(common.ModalHeight, common.ModalWidth).Render(title + "\n" + m.selectByModal.textInput.View() + "\n" + errorLine + "\n" + tip)
+}
+
 func (m *model) introduceModalRender() string {
 	title := common.SidebarTitleStyle.Render(" Thanks for using superfile!!") + common.ModalStyle.Render("\n You can read the following information before starting to use it!")
 	vimUserWarn := common.ProcessErrorStyle.Render("  ** Very importantly ** If you are a Vim/Nvim user, go to:\n  https://superfile.netlify.app/configure/custom-hotkeys/ to change your hotkey settings!")
@@ -544,7 +686,8 @@ func (m *model) sortOptionsRender() string {
 	return common.SortOptionsModalBorderStyle(panel.sortOptions.height, panel.sortOptions.width, bottomBorder).Render(sortOptionsContent)
 }
 
-func readFileContent(filepath string, maxLineLength int, previewLine int) (string, error) {
+// Read previewLine lines of the file starting from line firstLine, counting from 1
+func readFileContent(filepath string, maxLineLength int, firstLine int, previewLine int) (string, error) {
 	// String builder is much better for efficiency
 	// See - https://stackoverflow.com/questions/1760757/how-to-efficiently-concatenate-strings-in-go/47798475#47798475
 	var resultBuilder strings.Builder
@@ -556,7 +699,10 @@ func readFileContent(filepath string, maxLineLength int, previewLine int) (strin
 
 	scanner := bufio.NewScanner(file)
 	lineCount := 0
-	for scanner.Scan() {
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if lineNumber < firstLine {
+			continue
+		}
 		line := scanner.Text()
 		if len(line) > maxLineLength {
 			line = line[:maxLineLength]
@@ -575,7 +721,7 @@ func readFileContent(filepath string, maxLineLength int, previewLine int) (strin
 
 func (m *model) filePreviewPanelRender() string {
 	previewLine := m.mainPanelHeight + 2
-	m.fileModel.filePreview.width += m.fullWidth - common.Config.SidebarWidth - m.fileModel.filePreview.width - ((m.fileModel.width + 2) * len(m.fileModel.filePanels)) - 2
+	m.fileModel.filePreview.width += m.fullWidth - common.Config.SidebarWidth - m.fileModel.filePreview.width - m.parentColumnFullWidth() - ((m.fileModel.width + 2) * len(m.fileModel.filePanels)) - 2
 
 	panel := m.fileModel.filePanels[m.filePanelFocusIndex]
 	box := common.FilePreviewBox(previewLine, m.fileModel.filePreview.width)
@@ -593,6 +739,9 @@ func (m *model) filePreviewPanelRender() string {
 	// someone needs to scan through the entire codebase to figure out which access of panel
 	// data is causing crash.
 	itemPath := panel.element[panel.cursor].location
+	if diff := m.fileModel.filePreview.gitDiff; diff != nil && diff.location == itemPath {
+		return box.Render(gitDiffPreview(diff, previewLine, m.fileModel.filePreview.width))
+	}
 
 	// Renamed it to info_err to prevent shadowing with err below
 	fileInfo, infoErr := os.Stat(itemPath)
@@ -679,7 +828,10 @@ func (m *model) filePreviewPanelRender() string {
 	}
 
 	// At this point either format is not nil, or we can read the file
-	fileContent, err := readFileContent(itemPath, m.fileModel.width+20, previewLine)
+	// Content search results are previewed around their line
+	matchLine := panel.element[panel.cursor].line
+	firstLine := max(1, matchLine-previewLine/3)
+	fileContent, err := readFileContent(itemPath, m.fileModel.width+20, firstLine, previewLine)
 	if err != nil {
 		slog.Error("Error open file", "error", err)
 		return box.Render("\n --- " + icon.Error + " Error open file ---")
@@ -699,7 +851,7 @@ func (m *model) filePreviewPanelRender() string {
 			if batCmd == "" {
 				return box.Render("\n --- " + icon.Error + " 'bat' is not installed or not found. ---\n --- Cannot render file preview. ---")
 			}
-			fileContent, err = getBatSyntaxHighlightedContent(itemPath, previewLine, background)
+			fileContent, err = getBatSyntaxHighlightedContent(itemPath, firstLine, previewLine, matchLine, background)
 		} else {
 			fileContent, err = ansichroma.HightlightString(fileContent, format.Config().Name, common.Theme.CodeSyntaxHighlightTheme, background)
 		}
@@ -713,11 +865,15 @@ func (m *model) filePreviewPanelRender() string {
 	return box.Render(fileContent)
 }
 
-func getBatSyntaxHighlightedContent(itemPath string, previewLine int, background string) (string, error) {
+func getBatSyntaxHighlightedContent(itemPath string, firstLine int, previewLine int, matchLine int, background string) (string, error) {
 	// --plain: use the plain style without line numbers and decorations
 	// --force-colorization: force colorization for non-interactive shell output
-	// --line-range <:m>: only read from line 1 to line "m"
-	batArgs := []string{itemPath, "--plain", "--force-colorization", "--line-range", fmt.Sprintf(":%d", previewLine-1)}
+	// --line-range <n:m>: only read from line "n" to line "m"
+	batArgs := []string{itemPath, "--plain", "--force-colorization", "--line-range", fmt.Sprintf("%d:%d", firstLine, firstLine+previewLine-2)}
+	// --highlight-line <n>: highlight the matching line of a content search
+	if matchLine > 0 {
+		batArgs = append(batArgs, "--highlight-line", strconv.Itoa(matchLine))
+	}
 
 	// set timeout for the external command execution to 500ms max
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"log/slog"
+	"math"
+	"os"
+	"slices"
+
+	"github.com/shirou/gopsutil/v4/disk"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+// Total size of the items at paths, directories counting for the size of
+// their content. False while the size of a directory is computed in the
+// background by sizes, the total then leaves it out until the size comes in
+func itemsSize(paths []string, sizes *dirsize.Cache) (int64, bool) {
+	var total int64
+	complete := true
+	for _, path := range paths {
+		info, err := os.Lstat(path)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			total += info.Size()
+			continue
+		}
+		size, ok := sizes.Size(path, info.ModTime())
+		if !ok {
+			complete = false
+		}
+		total += size
+	}
+	return total, complete
+}
+
+// Total size of items shown in a footer, computed again only when the items
+// change or it is marked stale, instead of on every render
+type itemsSizeSummary struct {
+	paths    []string
+	size     int64
+	complete bool
+	computed bool
+}
+
+// Compute the total size of paths, unless it was already computed for them
+func (s *itemsSizeSummary) update(paths []string, sizes *dirsize.Cache) {
+	if s.computed && slices.Equal(s.paths, paths) {
+		return
+	}
+	s.size, s.complete = itemsSize(paths, sizes)
+	s.paths = slices.Clone(paths)
+	s.computed = true
+}
+
+// Free space check of the clipboard, done again only when the items, their
+// size or the focused location change, or it is marked stale
+type clipboardSpaceCheck struct {
+	location string
+	size     int64
+	cut      bool
+	warning  string
+	computed bool
+}
+
+// Compute the sizes shown in the footers of the file panels in select mode
+// and of the clipboard when they changed
+func (m *model) updateItemsSizes() {
+	for i := range m.fileModel.filePanels {
+		panel := &m.fileModel.filePanels[i]
+		if panel.panelMode == selectMode {
+			panel.selectedSize.update(panel.selected, m.dirSizes)
+		}
+	}
+	if len(m.copyItems.items) == 0 {
+		return
+	}
+	m.clipboardSize.update(m.copyItems.items, m.dirSizes)
+	location := m.fileModel.filePanels[m.filePanelFocusIndex].location
+	space := &m.clipboardSpace
+	if space.computed && space.location == location && space.size == m.clipboardSize.size &&
+		space.cut == m.copyItems.cut {
+		return
+	}
+	*space = clipboardSpaceCheck{
+		location: location,
+		size:     m.clipboardSize.size,
+		cut:      m.copyItems.cut,
+		warning:  m.clipboardSpaceWarning(m.clipboardSize.size),
+		computed: true,
+	}
+}
+
+// Compute the sizes shown in the footers again on the next update, the size
+// of directories came in or items changed on disk
+func (m *model) markItemsSizesStale() {
+	for i := range m.fileModel.filePanels {
+		m.fileModel.filePanels[i].selectedSize.computed = false
+	}
+	m.clipboardSize.computed = false
+	m.clipboardSpace.computed = false
+}
+
+// Formatted size of items, followed by a "+" while the size of directories
+// is still computed
+func formatItemsSize(size int64, complete bool) string {
+	if !complete {
+		return common.FormatFileSize(size) + "+"
+	}
+	return common.FormatFileSize(size)
+}
+
+// Warning shown in the clipboard when the filesystem of the focused panel has
+// less free space than the copied items take, empty otherwise. Cut items only
+// take space when they are moved to another filesystem
+func (m *model) clipboardSpaceWarning(size int64) string {
+	location := m.fileModel.filePanels[m.filePanelFocusIndex].location
+	if m.copyItems.cut {
+		moved := slices.DeleteFunc(slices.Clone(m.copyItems.items), func(item string) bool {
+			return utils.SameDevice(item, location)
+		})
+		if len(moved) == 0 {
+			return ""
+		}
+		size, _ = itemsSize(moved, m.dirSizes)
+	}
+	usage, err := disk.Usage(location)
+	if err != nil {
+		slog.Debug("Error while getting free space", "location", location, "error", err)
+		return ""
+	}
+	free := int64(min(usage.Free, math.MaxInt64))
+	if size <= free {
+		return ""
+	}
+	return "Not enough space, " + common.FormatFileSize(free) + " free"
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+func TestItemsSize(t *testing.T) {
+	dir := t.TempDir()
+	for name, size := range map[string]int{"a.txt": 100, "sub/b.txt": 20, "sub/nested/c.txt": 300} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), make([]byte, size), 0644))
+	}
+	paths := []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "sub"), filepath.Join(dir, "missing")}
+
+	// Directory sizes are computed in the background
+	sizes := dirsize.New(dirSizeWorkers, nil)
+	size, complete := itemsSize(paths, sizes)
+	if !complete {
+		assert.Equal(t, int64(100), size)
+		assert.Equal(t, "100.00 B+", formatItemsSize(size, complete))
+	}
+	require.Eventually(t, func() bool {
+		_, complete = itemsSize(paths, sizes)
+		return complete
+	}, time.Second, 10*time.Millisecond)
+	size, _ = itemsSize(paths, sizes)
+	assert.Equal(t, int64(420), size)
+	assert.Equal(t, "420.00 B", formatItemsSize(size, true))
+}
+
+func TestModel_ClipboardSummary(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(file, make([]byte, 100), 0644))
+	m := defaultModelConfig(false, true, []string{dir})
+	m.fullWidth = 150
+	m.footerHeight = 6
+
+	m.copyItems.items = []string{file}
+	m.updateItemsSizes()
+	assert.Contains(t, m.clipboardRender(), "1 item")
+	assert.Contains(t, m.clipboardRender(), "100.00 B")
+
+	// Sizes are computed again when the items change or a directory size
+	// comes in, not on every update
+	require.NoError(t, os.WriteFile(file, make([]byte, 200), 0644))
+	m.updateItemsSizes()
+	assert.Contains(t, m.clipboardRender(), "100.00 B")
+	m.handleDirSizes(nil)
+	m.updateItemsSizes()
+	assert.Contains(t, m.clipboardRender(), "200.00 B")
+	panel := &m.fileModel.filePanels[0]
+	panel.panelMode = selectMode
+	panel.selected = []string{file}
+	m.updateItemsSizes()
+	assert.Equal(t, int64(200), panel.selectedSize.size)
+	m.copyItems.items = append(m.copyItems.items, file)
+	m.updateItemsSizes()
+	assert.Contains(t, m.clipboardRender(), "400.00 B")
+
+	assert.Empty(t, m.clipboardSpaceWarning(100))
+	assert.Contains(t, m.clipboardSpaceWarning(math.MaxInt64), "Not enough space")
+	m.copyItems.cut = true
+	assert.Empty(t, m.clipboardSpaceWarning(math.MaxInt64), "cut items are moved within the filesystem")
+
+	assert.True(t, utils.SameDevice(file, dir))
+	assert.False(t, utils.SameDevice(file, filepath.Join(dir, "missing")))
+	if runtime.GOOS == "linux" {
+		assert.False(t, utils.SameDevice(file, "/proc"))
+	}
+}
@@ -0,0 +1,189 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	variable "github.com/yorukot/superfile/src/config"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+// Layout of superfile saved on quit and restored on the next launch
+type session struct {
+	FilePanels      []sessionFilePanel `json:"file_panels"`
+	FocusIndex      int                `json:"focus_index"`
+	FilePreviewOpen bool               `json:"file_preview_open"`
+	FooterOpen      bool               `json:"footer_open"`
+}
+
+type sessionFilePanel struct {
+	Tabs      []sessionTab `json:"tabs"`
+	ActiveTab int          `json:"active_tab"`
+}
+
+type sessionTab struct {
+	Location     string `json:"location"`
+	Cursor       int    `json:"cursor"`
+	Render       int    `json:"render"`
+	SortType     int    `json:"sort_type"`
+	SortReversed bool   `json:"sort_reversed"`
+	// Nil in sessions saved before directories first could be toggled
+	DirectoriesFirst *bool  `json:"directories_first,omitempty"`
+	SearchFilter     string `json:"search_filter"`
+	SearchMode       int    `json:"search_mode"`
+	SearchPinned     bool   `json:"search_pinned"`
+	SelectMode       bool   `json:"select_mode"`
+}
+
+// Whether the session should be saved on quit and restored on launch
+func sessionEnabled() bool {
+	return common.Config.SessionRestore || variable.SessionFromArg
+}
+
+func newSessionTab(tab filePanelTab) sessionTab {
+	return sessionTab{
+		Location:         tab.location,
+		Cursor:           tab.cursor,
+		Render:           tab.render,
+		SortType:         tab.sortOptions.selected,
+		SortReversed:     tab.sortOptions.reversed,
+		DirectoriesFirst: &tab.sortOptions.directoriesFirst,
+		SearchFilter:     tab.searchBarValue,
+		SearchMode:       int(tab.searchMode),
+		SearchPinned:     tab.searchPinned,
+		SelectMode:       tab.panelMode == selectMode,
+	}
+}
+
+// Current layout of the model as a session
+func (m *model) currentSession() session {
+	s := session{
+		FilePanels:      make([]sessionFilePanel, len(m.fileModel.filePanels)),
+		FocusIndex:      m.filePanelFocusIndex,
+		FilePreviewOpen: m.fileModel.filePreview.open,
+		FooterOpen:      m.toggleFooter,
+	}
+	for i, panel := range m.fileModel.filePanels {
+		panel.saveActiveTab()
+		tabs := make([]sessionTab, len(panel.tabs))
+		for j, tab := range panel.tabs {
+			tabs[j] = newSessionTab(tab)
+		}
+		s.FilePanels[i] = sessionFilePanel{Tabs: tabs, ActiveTab: panel.activeTab}
+	}
+	return s
+}
+
+func (m *model) saveSession(path string) error {
+	data, err := json.Marshal(m.currentSession())
+	if err != nil {
+		return fmt.Errorf("error marshaling session: %w", err)
+	}
+	if err = os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing session file: %w", err)
+	}
+	return nil
+}
+
+// Restore the layout saved in the session file at path. Tabs whose location
+// is no longer accessible are skipped. The model is left untouched if there is
+// no saved session or the session has no usable panel
+func (m *model) restoreSession(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		slog.Debug("No saved session to restore", "path", path)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading session file: %w", err)
+	}
+	var s session
+	if err = json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("error parsing session file: %w", err)
+	}
+
+	var panels []filePanel
+	focusIndex := 0
+	for i, savedPanel := range s.FilePanels {
+		panel, ok := restoreFilePanel(savedPanel)
+		if !ok {
+			continue
+		}
+		if i == s.FocusIndex {
+			focusIndex = len(panels)
+		}
+		panel.focusType = noneFocus
+		panels = append(panels, panel)
+	}
+	if len(panels) == 0 {
+		return errors.New("session has no accessible file panel")
+	}
+	panels[focusIndex].focusType = focus
+
+	m.fileModel.filePanels = panels
+	m.filePanelFocusIndex = focusIndex
+	m.fileModel.filePreview.open = s.FilePreviewOpen
+	m.toggleFooter = s.FooterOpen
+	return nil
+}
+
+func restoreFilePanel(savedPanel sessionFilePanel) (filePanel, bool) {
+	var tabs []filePanelTab
+	activeTab := 0
+	for i, savedTab := range savedPanel.Tabs {
+		if _, err := os.Stat(savedTab.Location); err != nil {
+			slog.Error("Skipping inaccessible session location", "location", savedTab.Location, "error", err)
+			continue
+		}
+		if i == savedPanel.ActiveTab {
+			activeTab = len(tabs)
+		}
+		tabs = append(tabs, restoreTab(savedTab))
+	}
+	if len(tabs) == 0 {
+		return filePanel{}, false
+	}
+
+	panel := defaultFilePanel(tabs[activeTab].location)
+	panel.tabs = tabs
+	panel.loadTab(activeTab)
+	if len(tabs) == 1 {
+		panel.tabs = nil
+	}
+	return panel, true
+}
+
+func restoreTab(savedTab sessionTab) filePanelTab {
+	sortOptions := defaultFilePanel("").sortOptions.data
+	if savedTab.SortType >= 0 && savedTab.SortType < len(sortOptions.options) {
+		sortOptions.selected = savedTab.SortType
+	}
+	sortOptions.reversed = savedTab.SortReversed
+	if savedTab.DirectoriesFirst != nil {
+		sortOptions.directoriesFirst = *savedTab.DirectoriesFirst
+	}
+
+	mode := browserMode
+	if savedTab.SelectMode {
+		mode = selectMode
+	}
+	searchMode := fuzzyMatch
+	if savedTab.SearchMode >= 0 && savedTab.SearchMode <= int(exactMatch) {
+		searchMode = matchMode(savedTab.SearchMode)
+	}
+	return filePanelTab{
+		location:         savedTab.Location,
+		cursor:           max(savedTab.Cursor, 0),
+		render:           max(savedTab.Render, 0),
+		sortOptions:      sortOptions,
+		panelMode:        mode,
+		selected:         []string{},
+		directoryRecords: make(map[string]directoryRecord),
+		searchBarValue:   savedTab.SearchFilter,
+		searchMode:       searchMode,
+		searchPinned:     savedTab.SearchPinned,
+	}
+}
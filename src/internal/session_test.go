@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModel_SaveAndRestoreSession(t *testing.T) {
+	dir := t.TempDir()
+	dir1 := filepath.Join(dir, "dir1")
+	dir2 := filepath.Join(dir, "dir2")
+	deletedDir := filepath.Join(dir, "deleted")
+	for _, d := range []string{dir1, dir2, deletedDir} {
+		require.NoError(t, os.Mkdir(d, 0755))
+	}
+	sessionFile := filepath.Join(dir, "session.json")
+
+	m := defaultModelConfig(false, true, []string{dir1, deletedDir, dir2})
+	m.fileModel.filePanels[0].cursor = 2
+	m.fileModel.filePanels[0].sortOptions.data.selected = 1
+	m.fileModel.filePanels[0].sortOptions.data.directoriesFirst = false
+	m.fileModel.filePanels[0].searchBar.SetValue("foo")
+	m.fileModel.filePanels[0].openNewTab()
+	m.fileModel.filePanels[0].location = dir2
+	m.fileModel.filePanels[2].panelMode = selectMode
+	m.filePanelFocusIndex = 2
+	m.fileModel.filePreview.open = true
+	m.toggleFooter = false
+	require.NoError(t, m.saveSession(sessionFile))
+
+	require.NoError(t, os.Remove(deletedDir))
+	restored := defaultModelConfig(false, true, []string{dir})
+	require.NoError(t, restored.restoreSession(sessionFile))
+
+	panels := restored.fileModel.filePanels
+	require.Len(t, panels, 2, "panel with inaccessible location should be skipped")
+	assert.Equal(t, 1, restored.filePanelFocusIndex)
+	assert.Equal(t, focus, panels[1].focusType)
+	assert.Equal(t, noneFocus, panels[0].focusType)
+	assert.True(t, restored.fileModel.filePreview.open)
+	assert.False(t, restored.toggleFooter)
+
+	require.Equal(t, 2, panels[0].tabCount())
+	assert.Equal(t, dir2, panels[0].location)
+	panels[0].switchTab(1)
+	assert.Equal(t, dir1, panels[0].location)
+	assert.Equal(t, 2, panels[0].cursor)
+	assert.Equal(t, 1, panels[0].sortOptions.data.selected)
+	assert.False(t, panels[0].sortOptions.data.directoriesFirst)
+	assert.Equal(t, "foo", panels[0].searchBar.Value())
+
+	assert.Equal(t, dir2, panels[1].location)
+	assert.Equal(t, selectMode, panels[1].panelMode)
+
+	t.Run("Missing session file keeps the model", func(t *testing.T) {
+		m := defaultModelConfig(false, true, []string{dir})
+		require.NoError(t, m.restoreSession(filepath.Join(dir, "missing.json")))
+		assert.Equal(t, dir, m.fileModel.filePanels[0].location)
+	})
+}
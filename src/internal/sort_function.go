@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"cmp"
+	"mime"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+// Type of directories, compared with the MIME types of files
+const directoryMimeType = "inode/directory"
+
+// Sort entries in place with the given options. Items with equal sort keys
+// are sorted by name
+func sortEntries(entries []listedEntry, sortOptions sortOptionsModelData, sizes *dirsize.Cache) {
+	fillSortKeys(entries, sortOptions.selected, sizes)
+	compare := entryComparison(sortOptions.selected)
+	slices.SortStableFunc(entries, func(a, b listedEntry) int {
+		// Directories stay first in reversed order
+		if sortOptions.directoriesFirst && a.IsDir() != b.IsDir() {
+			if a.IsDir() {
+				return -1
+			}
+			return 1
+		}
+		if sortOptions.reversed {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
+}
+
+// Read the sort keys that are costly to get once per entry, instead of on
+// each comparison. Directory sizes are read from sizes
+func fillSortKeys(entries []listedEntry, sortType int, sizes *dirsize.Cache) {
+	for i := range entries {
+		e := &entries[i]
+		switch sortType {
+		case common.SortBySize:
+			e.sortSize = e.info.Size()
+			// Directories count as empty until their size comes in, then the
+			// panel is sorted again by handleDirSizes
+			if e.IsDir() {
+				e.sortSize, _ = sizes.Size(filepath.Join(e.dir, e.Name()), e.info.ModTime())
+			}
+		case common.SortByDateModified:
+			e.sortTime = e.info.ModTime()
+		case common.SortByDateCreated:
+			e.sortTime = utils.FileBirthTime(filepath.Join(e.dir, e.Name()), e.info)
+		case common.SortByDateAccessed:
+			e.sortTime = utils.FileAccessTime(e.info)
+		}
+	}
+}
+
+// Ascending order of the given sort type. Dates are sorted from the newest
+func entryComparison(sortType int) func(a, b listedEntry) int {
+	switch sortType {
+	case common.SortBySize:
+		return func(a, b listedEntry) int {
+			return cmp.Or(cmp.Compare(a.sortSize, b.sortSize), compareNames(a, b))
+		}
+	case common.SortByDateModified, common.SortByDateCreated, common.SortByDateAccessed:
+		return func(a, b listedEntry) int {
+			return cmp.Or(b.sortTime.Compare(a.sortTime), compareNames(a, b))
+		}
+	case common.SortByNatural:
+		return func(a, b listedEntry) int {
+			if common.Config.CaseSensitiveSort {
+				return common.NaturalCompare(a.name, b.name)
+			}
+			return common.NaturalCompare(strings.ToLower(a.name), strings.ToLower(b.name))
+		}
+	case common.SortByExtension:
+		return func(a, b listedEntry) int {
+			return cmp.Or(strings.Compare(entryExtension(a), entryExtension(b)), compareNames(a, b))
+		}
+	case common.SortByType:
+		return func(a, b listedEntry) int {
+			return cmp.Or(strings.Compare(entryMimeType(a), entryMimeType(b)), compareNames(a, b))
+		}
+	}
+	return compareNames
+}
+
+func compareNames(a, b listedEntry) int {
+	if common.Config.CaseSensitiveSort {
+		return strings.Compare(a.name, b.name)
+	}
+	return strings.Compare(strings.ToLower(a.name), strings.ToLower(b.name))
+}
+
+// Lower case extension of a file, without the dot. Directories have none
+func entryExtension(e listedEntry) string {
+	if e.IsDir() {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(e.Name()), "."))
+}
+
+// MIME type of an entry, guessed from its extension
+func entryMimeType(e listedEntry) string {
+	if e.IsDir() {
+		return directoryMimeType
+	}
+	mimeType := mime.TypeByExtension(strings.ToLower(filepath.Ext(e.Name())))
+	if mimeType == "" {
+		return "application/octet-stream"
+	}
+	// Drop parameters like the charset
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	return mimeType
+}
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func Test_sortEntries(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	files := []struct {
+		name     string
+		size     int
+		modified time.Time
+		accessed time.Time
+	}{
+		{"file10.txt", 30, now.Add(-3 * time.Hour), now.Add(-1 * time.Hour)},
+		{"file2.png", 10, now.Add(-1 * time.Hour), now.Add(-3 * time.Hour)},
+		{"notes.md", 20, now.Add(-2 * time.Hour), now.Add(-2 * time.Hour)},
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		require.NoError(t, os.WriteFile(path, make([]byte, f.size), 0644))
+		require.NoError(t, os.Chtimes(path, f.accessed, f.modified))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub1", "child"), 0755))
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "sub1"), now, now))
+
+	names := func(sortType int, reversed bool, directoriesFirst bool) []string {
+		sortOptions := sortOptionsModelData{
+			options:          common.SortTypeNames(),
+			selected:         sortType,
+			reversed:         reversed,
+			directoriesFirst: directoriesFirst,
+		}
+		var result []string
+		for _, e := range returnDirElement(dir, false, sortOptions, nil) {
+			result = append(result, e.name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name             string
+		sortType         int
+		reversed         bool
+		directoriesFirst bool
+		expected         []string
+	}{
+		{"Name", common.SortByName, false, true, []string{"sub1", "file10.txt", "file2.png", "notes.md"}},
+		{"Name reversed", common.SortByName, true, true, []string{"sub1", "notes.md", "file2.png", "file10.txt"}},
+		{"Name mixed", common.SortByName, true, false, []string{"sub1", "notes.md", "file2.png", "file10.txt"}},
+		{"Natural", common.SortByNatural, false, true, []string{"sub1", "file2.png", "file10.txt", "notes.md"}},
+		{"Size", common.SortBySize, false, true, []string{"sub1", "file2.png", "notes.md", "file10.txt"}},
+		{"Size reversed mixed", common.SortBySize, true, false, []string{"file10.txt", "notes.md", "file2.png", "sub1"}},
+		{"Date Modified", common.SortByDateModified, false, false, []string{"sub1", "file2.png", "notes.md", "file10.txt"}},
+		{"Extension", common.SortByExtension, false, false, []string{"sub1", "notes.md", "file2.png", "file10.txt"}},
+		{"Type", common.SortByType, false, true, []string{"sub1", "file2.png", "notes.md", "file10.txt"}},
+		{"Date Accessed", common.SortByDateAccessed, false, true, []string{"sub1", "file10.txt", "notes.md", "file2.png"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, names(tt.sortType, tt.reversed, tt.directoriesFirst))
+		})
+	}
+
+	// Creation times can't be set, but all the items are listed
+	assert.ElementsMatch(t, names(common.SortByName, false, true), names(common.SortByDateCreated, false, true))
+}
+
+func TestModel_ToggleDirectoriesFirst(t *testing.T) {
+	m := defaultModelConfig(false, true, []string{t.TempDir()})
+	panel := &m.fileModel.filePanels[0]
+	require.True(t, panel.sortOptions.data.directoriesFirst)
+	m.mainKey(common.Hotkeys.ToggleDirectoriesFirst[0], nil)
+	assert.False(t, panel.sortOptions.data.directoriesFirst)
+}
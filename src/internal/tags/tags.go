@@ -0,0 +1,221 @@
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+// Tags of a file, as saved in the database
+type entry struct {
+	Tags []string `json:"tags"`
+	// Whether the tags are in the extended attribute of the file too. The
+	// attribute is then authoritative, as other programs can change it
+	InAttr bool `json:"in_attr,omitempty"`
+}
+
+// Tags of files. They are stored in the user.xdg.tags extended attribute of
+// the files, which other file managers read too, and in the database file.
+// The database is the only storage for files whose filesystem lacks extended
+// attributes, and the index of the tagged files for the others. Files tagged
+// by other programs are indexed when their tags are first read.
+// A nil *Store has no tags, and fails to set any
+type Store struct {
+	file    string
+	entries map[string]entry
+	// Files whose extended attribute was read or written. The attribute of the
+	// others is read when their tags are first asked for, and again once
+	// unchecked with Uncheck
+	checked map[string]bool
+}
+
+func New(file string) *Store {
+	return &Store{
+		file:    file,
+		entries: make(map[string]entry),
+		checked: make(map[string]bool),
+	}
+}
+
+// Load the database saved in file, empty when it can't be read. Entries of
+// files that no longer exist are dropped
+func Load(file string) *Store {
+	s := New(file)
+	var entries map[string]entry
+	if !utils.ReadJSONFile(file, &entries) || entries == nil {
+		return s
+	}
+	s.entries = entries
+	for path := range s.entries {
+		if _, err := os.Lstat(path); errors.Is(err, os.ErrNotExist) {
+			delete(s.entries, path)
+		}
+	}
+	return s
+}
+
+// Tags are stored comma separated, and trimmed
+func IsValidTag(tag string) bool {
+	return tag != "" && tag == strings.TrimSpace(tag) && !strings.Contains(tag, ",")
+}
+
+func (s *Store) save() error {
+	return utils.WriteJSONFile(s.file, s.entries)
+}
+
+// Read the extended attribute of path and index its tags
+func (s *Store) check(path string) {
+	s.checked[path] = true
+	if e, ok := s.entries[path]; ok && !e.InAttr {
+		return
+	}
+	tags, err := readAttr(path)
+	if err != nil {
+		if !errors.Is(err, errUnsupported) && !errors.Is(err, os.ErrNotExist) {
+			slog.Debug("Error while reading tags attribute", "path", path, "error", err)
+		}
+		return
+	}
+	if slices.Equal(tags, s.entries[path].Tags) {
+		return
+	}
+	if len(tags) == 0 {
+		delete(s.entries, path)
+	} else {
+		s.entries[path] = entry{Tags: tags, InAttr: true}
+	}
+	if err = s.save(); err != nil {
+		slog.Error("Error while saving tags", "error", err)
+	}
+}
+
+// Tags of the file at path, in the order they were added
+func (s *Store) Tags(path string) []string {
+	if s == nil {
+		return nil
+	}
+	if !s.checked[path] {
+		s.check(path)
+	}
+	return s.entries[path].Tags
+}
+
+func (s *Store) Has(path string, tag string) bool {
+	return slices.Contains(s.Tags(path), tag)
+}
+
+// Replace the tags of the file at path. They are stored in the database only
+// when the extended attribute can't be written
+func (s *Store) Set(path string, tags []string) error {
+	if s == nil {
+		return errors.New("tags are not available")
+	}
+	var unique []string
+	for _, tag := range tags {
+		if !IsValidTag(tag) {
+			return fmt.Errorf("invalid tag %q", tag)
+		}
+		if !slices.Contains(unique, tag) {
+			unique = append(unique, tag)
+		}
+	}
+	tags = unique
+	err := writeAttr(path, tags)
+	if err != nil && !errors.Is(err, errUnsupported) {
+		slog.Debug("Error while writing tags attribute, keeping tags in the database", "path", path, "error", err)
+	}
+	s.checked[path] = true
+	if len(tags) == 0 {
+		delete(s.entries, path)
+	} else {
+		s.entries[path] = entry{Tags: tags, InAttr: err == nil}
+	}
+	return s.save()
+}
+
+func (s *Store) Add(path string, tag string) error {
+	tags := s.Tags(path)
+	if slices.Contains(tags, tag) {
+		return nil
+	}
+	return s.Set(path, append(slices.Clone(tags), tag))
+}
+
+func (s *Store) Remove(path string, tag string) error {
+	tags := s.Tags(path)
+	if !slices.Contains(tags, tag) {
+		return nil
+	}
+	return s.Set(path, slices.DeleteFunc(slices.Clone(tags), func(t string) bool { return t == tag }))
+}
+
+// Update the database after the file at oldPath, and everything below it,
+// was moved to newPath. Extended attributes move with the files
+func (s *Store) Move(oldPath string, newPath string) error {
+	if s == nil {
+		return nil
+	}
+	moved := make(map[string]entry)
+	for path, e := range s.entries {
+		rel, err := filepath.Rel(oldPath, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		delete(s.entries, path)
+		delete(s.checked, path)
+		moved[filepath.Join(newPath, rel)] = e
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+	for path := range moved {
+		delete(s.checked, path)
+	}
+	maps.Copy(s.entries, moved)
+	return s.save()
+}
+
+// Uncheck forgets that the extended attribute of the files matching match
+// was read, so that tags changed by other programs are read the next time
+// the tags of the files are asked for
+func (s *Store) Uncheck(match func(path string) bool) {
+	if s == nil {
+		return
+	}
+	maps.DeleteFunc(s.checked, func(path string, _ bool) bool {
+		return match(path)
+	})
+}
+
+// All the tags in use, sorted
+func (s *Store) All() []string {
+	if s == nil {
+		return nil
+	}
+	var tags []string
+	for _, e := range s.entries {
+		tags = append(tags, e.Tags...)
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
+
+// Paths of the tagged files, sorted
+func (s *Store) Files() []string {
+	if s == nil {
+		return nil
+	}
+	files := make([]string, 0, len(s.entries))
+	for path := range s.entries {
+		files = append(files, path)
+	}
+	slices.Sort(files)
+	return files
+}
@@ -0,0 +1,125 @@
+package tags
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createFiles(t *testing.T, dir string, names ...string) []string {
+	t.Helper()
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(paths[i]), 0755))
+		require.NoError(t, os.WriteFile(paths[i], nil, 0644))
+	}
+	return paths
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "tags.json")
+	paths := createFiles(t, dir, "a.txt", "b.txt", "c.txt")
+	s := Load(file)
+	assert.Empty(t, s.Tags(paths[0]))
+
+	require.NoError(t, s.Add(paths[0], "todo"))
+	require.NoError(t, s.Add(paths[0], "red"))
+	require.NoError(t, s.Add(paths[0], "todo"))
+	require.NoError(t, s.Set(paths[1], []string{"red", "red"}))
+	require.NoError(t, s.Add(paths[2], "done"))
+	require.NoError(t, s.Remove(paths[2], "done"))
+	require.Error(t, s.Add(paths[2], "a,b"))
+
+	assert.Equal(t, []string{"todo", "red"}, s.Tags(paths[0]))
+	assert.True(t, s.Has(paths[1], "red"))
+	assert.False(t, s.Has(paths[1], "todo"))
+	assert.Equal(t, []string{"red", "todo"}, s.All())
+	assert.Equal(t, paths[:2], s.Files())
+
+	// Changes are saved immediately
+	loaded := Load(file)
+	assert.Equal(t, paths[:2], loaded.Files())
+	assert.Equal(t, []string{"todo", "red"}, loaded.Tags(paths[0]))
+	assert.Empty(t, loaded.Tags(paths[2]))
+
+	// Entries of missing files are dropped on load
+	require.NoError(t, os.Remove(paths[1]))
+	assert.Equal(t, paths[:1], Load(file).Files())
+}
+
+func TestMove(t *testing.T) {
+	dir := t.TempDir()
+	paths := createFiles(t, dir, "sub/a.txt", "sub/deeper/b.txt", "subway.txt")
+	s := New(filepath.Join(dir, "tags.json"))
+	for _, path := range paths {
+		require.NoError(t, s.Add(path, "todo"))
+	}
+
+	newDir := filepath.Join(dir, "moved")
+	require.NoError(t, os.Rename(filepath.Join(dir, "sub"), newDir))
+	require.NoError(t, s.Move(filepath.Join(dir, "sub"), newDir))
+	assert.Equal(t, []string{
+		filepath.Join(newDir, "a.txt"),
+		filepath.Join(newDir, "deeper", "b.txt"),
+		paths[2],
+	}, s.Files())
+	assert.True(t, s.Has(filepath.Join(newDir, "deeper", "b.txt"), "todo"))
+}
+
+// Files tagged by other programs are indexed once their tags are read
+func TestTagsFromAttribute(t *testing.T) {
+	dir := t.TempDir()
+	paths := createFiles(t, dir, "a.txt")
+	err := writeAttr(paths[0], []string{"reviewed", "green"})
+	if errors.Is(err, errUnsupported) {
+		t.Skip("extended attributes are not supported in", dir)
+	}
+	require.NoError(t, err)
+
+	s := New(filepath.Join(dir, "tags.json"))
+	assert.Empty(t, s.Files())
+	assert.Equal(t, []string{"reviewed", "green"}, s.Tags(paths[0]))
+	assert.Equal(t, paths, s.Files())
+
+	require.NoError(t, s.Remove(paths[0], "reviewed"))
+	tags, err := readAttr(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"green"}, tags)
+
+	// The attribute is authoritative
+	require.NoError(t, writeAttr(paths[0], nil))
+	assert.Empty(t, Load(s.file).Tags(paths[0]))
+
+	// Changes by other programs are read once the file is unchecked
+	require.NoError(t, writeAttr(paths[0], []string{"blue"}))
+	assert.Equal(t, []string{"green"}, s.Tags(paths[0]))
+	s.Uncheck(func(path string) bool { return path == paths[0] })
+	assert.Equal(t, []string{"blue"}, s.Tags(paths[0]))
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "tags.json")
+	require.NoError(t, os.WriteFile(file, []byte("not json"), 0644))
+	s := Load(file)
+	assert.Empty(t, s.Files())
+	require.NoError(t, s.Add(createFiles(t, dir, "a.txt")[0], "todo"))
+}
+
+func TestNilStore(t *testing.T) {
+	var s *Store
+	assert.Empty(t, s.Tags("/tmp"))
+	assert.False(t, s.Has("/tmp", "todo"))
+	assert.Empty(t, s.All())
+	assert.Empty(t, s.Files())
+	require.Error(t, s.Add("/tmp", "todo"))
+	require.NoError(t, s.Remove("/tmp", "todo"))
+	require.NoError(t, s.Move("/tmp", "/var/tmp"))
+	s.Uncheck(func(string) bool { return true })
+}
@@ -0,0 +1,7 @@
+//go:build darwin || freebsd
+
+package tags
+
+import "golang.org/x/sys/unix"
+
+const errNoAttr = unix.ENOATTR
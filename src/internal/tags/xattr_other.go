@@ -0,0 +1,16 @@
+//go:build !linux && !darwin && !freebsd
+
+package tags
+
+import "errors"
+
+var errUnsupported = errors.New("extended attributes are not supported")
+
+// Tags are kept in the database only
+func readAttr(_ string) ([]string, error) {
+	return nil, errUnsupported
+}
+
+func writeAttr(_ string, _ []string) error {
+	return errUnsupported
+}
@@ -0,0 +1,65 @@
+//go:build linux || darwin || freebsd
+
+package tags
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// Tags attribute of the shared metadata specification of freedesktop.org
+const attrName = "user.xdg.tags"
+
+var errUnsupported = errors.New("extended attributes are not supported")
+
+// Errors of filesystems without user extended attributes. Linux doesn't allow
+// them on symbolic links and special files either
+func isUnsupported(err error) bool {
+	return errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EPERM)
+}
+
+// Tags in the extended attribute of path, none if it has no such attribute
+func readAttr(path string) ([]string, error) {
+	buf := make([]byte, 256)
+	size, err := unix.Getxattr(path, attrName, buf)
+	if errors.Is(err, unix.ERANGE) {
+		if size, err = unix.Getxattr(path, attrName, nil); err == nil {
+			buf = make([]byte, size)
+			size, err = unix.Getxattr(path, attrName, buf)
+		}
+	}
+	switch {
+	case errors.Is(err, errNoAttr):
+		return nil, nil
+	case isUnsupported(err):
+		return nil, errUnsupported
+	case err != nil:
+		return nil, err
+	}
+	var tags []string
+	for _, tag := range strings.Split(string(buf[:size]), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// Replace the extended attribute of path with tags, removing it when there
+// are none
+func writeAttr(path string, tags []string) error {
+	var err error
+	if len(tags) == 0 {
+		if err = unix.Removexattr(path, attrName); errors.Is(err, errNoAttr) {
+			return nil
+		}
+	} else {
+		err = unix.Setxattr(path, attrName, []byte(strings.Join(tags, ",")), 0)
+	}
+	if isUnsupported(err) {
+		return errUnsupported
+	}
+	return err
+}
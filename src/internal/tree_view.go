@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/ignore"
+)
+
+// Indentation guides of the tree view
+const (
+	treeBranch     = "├─ "
+	treeLastBranch = "└─ "
+	treeLine       = "│  "
+	treeBlank      = "   "
+)
+
+// Return the items of location, with the content of the expanded directories
+// listed right below them. Each level is sorted on its own. Ignored items are
+// left out
+func returnTreeElements(location string, displayDotFile bool, sortOptions sortOptionsModelData,
+	expandedDirs map[string]bool, ignored *ignore.Matcher, sizes *dirsize.Cache) []element {
+	return appendTreeLevel(nil, location, displayDotFile, sortOptions, expandedDirs, ignored, sizes, 0, "")
+}
+
+// Append the items of dir at the given depth. guide is the part of the
+// indentation guides coming from the levels above
+func appendTreeLevel(elements []element, dir string, displayDotFile bool, sortOptions sortOptionsModelData,
+	expandedDirs map[string]bool, ignored *ignore.Matcher, sizes *dirsize.Cache, depth int, guide string) []element {
+	children := filterIgnored(returnDirElement(dir, displayDotFile, sortOptions, sizes), ignored)
+	for i, child := range children {
+		childGuide := ""
+		// Items of the panel location are not indented
+		if depth > 0 {
+			if i == len(children)-1 {
+				child.treePrefix = guide + treeLastBranch
+				childGuide = guide + treeBlank
+			} else {
+				child.treePrefix = guide + treeBranch
+				childGuide = guide + treeLine
+			}
+		}
+		child.depth = depth
+		elements = append(elements, child)
+		// Symlinks are not expanded, so that a link to an ancestor can't loop
+		if child.directory && expandedDirs[child.location] {
+			elements = appendTreeLevel(elements, child.location, displayDotFile, sortOptions,
+				expandedDirs, ignored, sizes, depth+1, childGuide)
+		}
+	}
+	return elements
+}
+
+// Items listed by the panel before the search filter is applied: all the
+// items below its location in flatten mode, a tree in tree view. Ignored
+// items are left out when hidden, and only the items carrying the tag of the
+// tag filter are kept. The directories read are kept in panel.listedDirs
+func (m *model) dirElements(panel *filePanel) []element {
+	m.uncheckPanelTags(panel)
+	var elements []element
+	ignored := panel.ignoreMatcher()
+	switch {
+	case panel.flatten:
+		elements = m.flatElements(panel, ignored)
+	case panel.showsTree():
+		elements = returnTreeElements(panel.location, m.toggleDotFile, panel.sortOptions.data,
+			panel.expandedDirs, ignored, m.dirSizes)
+	default:
+		elements = filterIgnored(returnDirElement(panel.location, m.toggleDotFile, panel.sortOptions.data,
+			m.dirSizes), ignored)
+	}
+	panel.listedDirs = []string{panel.location}
+	if panel.flatten || panel.showsTree() {
+		for _, item := range elements {
+			if item.directory && (panel.flatten || panel.expandedDirs[item.location]) {
+				panel.listedDirs = append(panel.listedDirs, item.location)
+			}
+		}
+	}
+	if panel.tagFilter != "" {
+		return filterTagged(elements, panel.tagFilter, m.fileTags)
+	}
+	return elements
+}
+
+// Whether the panel currently lists its items as a tree. Search results and
+// tagged items are listed without the tree
+func (panel *filePanel) showsTree() bool {
+	return panel.treeView && !panel.flatten && panel.searchBar.Value() == "" && panel.tagFilter == "" && !panel.finder.active
+}
+
+// Reload the items of the panel right away, with the cursor on the item at
+// location, or else on its closest listed ancestor
+func (m *model) reloadPanelElements(panel *filePanel, location string) {
+	// Search results are refreshed by getFilePanelItems
+	panel.markListingStale()
+	if panel.searchBar.Value() != "" {
+		panel.lastTimeGetElement = time.Time{}
+		return
+	}
+	// The cursor is placed once the items come in
+	if panel.flatten {
+		panel.flatWalk.cursorLocation = location
+	}
+	panel.element = m.dirElements(panel)
+	panel.lastTimeGetElement = time.Now()
+	// Watched panels don't need to read the new listing again
+	panel.watchedListing = panel.listingState(m.toggleDotFile)
+	for ; location != panel.location && location != filepath.Dir(location); location = filepath.Dir(location) {
+		for i, item := range panel.element {
+			if item.location == location {
+				panel.cursor = i
+				panel.render = min(panel.render, panel.cursor)
+				panel.render = max(panel.render, panel.cursor-panelElementHeight(m.mainPanelHeight)+1)
+				return
+			}
+		}
+	}
+	panel.cursor = min(panel.cursor, max(0, len(panel.element)-1))
+	panel.render = min(panel.render, panel.cursor)
+}
+
+func (m *model) toggleTreeView() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	panel.treeView = !panel.treeView
+	if panel.flatten || panel.finder.active || len(panel.element) == 0 {
+		return
+	}
+	m.reloadPanelElements(panel, panel.element[panel.cursor].location)
+}
+
+// Expand or collapse the directory under the cursor in tree view
+func (m *model) toggleTreeNode() {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	if !panel.showsTree() || len(panel.element) == 0 || !panel.element[panel.cursor].directory {
+		return
+	}
+	location := panel.element[panel.cursor].location
+	if panel.expandedDirs[location] {
+		delete(panel.expandedDirs, location)
+	} else {
+		if _, err := os.ReadDir(location); err != nil {
+			return
+		}
+		if panel.expandedDirs == nil {
+			panel.expandedDirs = make(map[string]bool)
+		}
+		panel.expandedDirs[location] = true
+	}
+	m.reloadPanelElements(panel, location)
+}
+
+// Collapse the directory under the cursor, or else the one containing the
+// item under the cursor. Returns false if the cursor is on a collapsed item of
+// the panel location
+func (m *model) collapseTreeNode() bool {
+	panel := &m.fileModel.filePanels[m.filePanelFocusIndex]
+	if len(panel.element) == 0 {
+		return false
+	}
+	item := panel.element[panel.cursor]
+	if panel.expandedDirs[item.location] {
+		delete(panel.expandedDirs, item.location)
+		m.reloadPanelElements(panel, item.location)
+		return true
+	}
+	if item.depth == 0 {
+		return false
+	}
+	parent := filepath.Dir(item.location)
+	delete(panel.expandedDirs, parent)
+	m.reloadPanelElements(panel, parent)
+	return true
+}
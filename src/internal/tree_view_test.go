@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func setupTreeDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, d := range []string{"a/c", "a/b", "z"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+	}
+	for _, f := range []string{"a/c/y.txt", "a/x.txt", "file.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, f), nil, 0644))
+	}
+	return dir
+}
+
+func Test_returnTreeElements(t *testing.T) {
+	dir := setupTreeDir(t)
+	sortOptions := defaultFilePanel(dir).sortOptions.data
+	rows := func(elements []element) []string {
+		var result []string
+		for _, e := range elements {
+			result = append(result, e.treePrefix+e.name)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"a", "z", "file.txt"},
+		rows(returnTreeElements(dir, false, sortOptions, nil, nil, nil)))
+
+	expanded := map[string]bool{
+		filepath.Join(dir, "a"):   true,
+		filepath.Join(dir, "a/c"): true,
+	}
+	elements := returnTreeElements(dir, false, sortOptions, expanded, nil, nil)
+	assert.Equal(t, []string{
+		"a",
+		treeBranch + "b",
+		treeBranch + "c",
+		treeLine + treeLastBranch + "y.txt",
+		treeLastBranch + "x.txt",
+		"z",
+		"file.txt",
+	}, rows(elements))
+	assert.Equal(t, 2, elements[3].depth)
+	assert.Equal(t, filepath.Join(dir, "a/c/y.txt"), elements[3].location)
+
+	// Each level is sorted on its own
+	sortOptions.reversed = true
+	assert.Equal(t, []string{"z", "a", treeBranch + "c", treeLine + treeLastBranch + "y.txt", treeBranch + "b",
+		treeLastBranch + "x.txt", "file.txt"}, rows(returnTreeElements(dir, false, sortOptions, expanded, nil, nil)))
+}
+
+func TestModel_TreeView(t *testing.T) {
+	dir := setupTreeDir(t)
+	m := defaultModelConfig(false, true, []string{dir})
+	panel := &m.fileModel.filePanels[0]
+	panel.focusType = focus
+	m.getFilePanelItems()
+
+	m.toggleTreeView()
+	require.True(t, panel.treeView)
+
+	// Directories are expanded by their own hotkey
+	m.normalAndBrowserModeKey(common.Hotkeys.ToggleTreeNode[0])
+	assert.Equal(t, dir, panel.location)
+	require.Len(t, panel.element, 6)
+	panel.cursor = 2
+	m.toggleTreeNode()
+	require.Len(t, panel.element, 7)
+	assert.Equal(t, filepath.Join(dir, "a/c/y.txt"), panel.element[3].location)
+
+	// File operations act on the node under the cursor
+	panel.cursor = 3
+	m.copySingleItem(false)
+	assert.Equal(t, []string{filepath.Join(dir, "a/c/y.txt")}, m.copyItems.items)
+	m.panelItemRename()
+	panel.rename.SetValue("renamed.txt")
+	assert.False(t, m.IsRenamingConflicting())
+	m.confirmRename()
+	assert.FileExists(t, filepath.Join(dir, "a/c/renamed.txt"))
+
+	// Parent directory collapses the directory containing the cursor
+	m.parentDirectory()
+	assert.Equal(t, dir, panel.location)
+	assert.Equal(t, 2, panel.cursor)
+	assert.Len(t, panel.element, 6)
+	m.parentDirectory()
+	assert.Equal(t, 0, panel.cursor)
+	assert.Len(t, panel.element, 3)
+
+	// Expansion state is kept when the tree view is toggled off and on
+	panel.expandedDirs[filepath.Join(dir, "z")] = true
+	m.toggleTreeView()
+	m.getFilePanelItems()
+	assert.Len(t, panel.element, 3)
+	m.toggleTreeView()
+	m.getFilePanelItems()
+	assert.Len(t, panel.element, 3, "empty directory adds no items")
+	m.parentDirectory()
+	assert.Equal(t, filepath.Dir(dir), panel.location)
+
+	// Confirm enters the directory as in browser mode
+	m.getFilePanelItems()
+	panel.cursor = slices.IndexFunc(panel.element, func(e element) bool { return e.location == dir })
+	require.GreaterOrEqual(t, panel.cursor, 0)
+	m.enterPanel()
+	assert.Equal(t, dir, panel.location)
+	assert.True(t, panel.showsTree())
+}
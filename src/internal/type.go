@@ -1,13 +1,25 @@
 package internal
 
 import (
+	"context"
+	"os"
 	"time"
 
 	"github.com/yorukot/superfile/src/internal/ui/sidebar"
 
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yorukot/superfile/src/internal/dirsize"
+	"github.com/yorukot/superfile/src/internal/dirsort"
+	"github.com/yorukot/superfile/src/internal/dirwatch"
+	"github.com/yorukot/superfile/src/internal/frecency"
+	"github.com/yorukot/superfile/src/internal/gitstatus"
+	"github.com/yorukot/superfile/src/internal/marks"
+	"github.com/yorukot/superfile/src/internal/tags"
+	"github.com/yorukot/superfile/src/internal/ui/picker"
 	"github.com/yorukot/superfile/src/internal/ui/prompt"
+	"github.com/yorukot/superfile/src/internal/utils"
 )
 
 // Type representing the mode of the panel
@@ -37,6 +49,7 @@ const (
 const (
 	confirmDeleteItem warnType = iota
 	confirmRenameItem
+	confirmGitDiscard
 )
 
 // Constants for panel with no focus
@@ -72,6 +85,13 @@ const (
 	sendWarnModal channelMessageType = iota
 	sendMetadata
 	sendProcess
+	sendFindResults
+	sendFuzzySearchResults
+	sendFlatElements
+	sendDirSizes
+	sendDirChanges
+	sendGitStatus
+	sendGitDiff
 )
 
 // Main model
@@ -81,10 +101,29 @@ type model struct {
 	processBarModel      processBarModel
 	focusPanel           focusPanelType
 	copyItems            copyItems
+	clipboardSize        itemsSizeSummary
+	clipboardSpace       clipboardSpaceCheck
 	typingModal          typingModal
 	warnModal            warnModal
 	helpMenu             helpMenuModal
 	promptModal          prompt.Model
+	historyModal         picker.Model
+	jumpModal            picker.Model
+	frecency             *frecency.Database
+	dirSizes             *dirsize.Cache
+	dirSorts             *dirsort.Store
+	dirWatcher           *dirwatch.Watcher
+	gitStatuses          *gitstatus.Cache
+	fileTags             *tags.Store
+	marksModal           marksModal
+	marks                *marks.Store
+	pendingMark          pendingMarkAction
+	tagModal             tagModal
+	tagFilterModal       picker.Model
+	selectByModal        selectByModal
+	lastFindID           int
+	lastFuzzySearchID    int
+	lastFlatWalkID       int
 	fileMetaData         fileMetadata
 	confirmToQuit        bool
 	firstTextInput       bool
@@ -93,6 +132,7 @@ type model struct {
 	toggleFooter         bool
 	firstLoadingComplete bool
 	filePanelFocusIndex  int
+	lastClick            mouseClickRecord
 
 	// Height in number of lines of actual viewport of
 	// main panel and sidebar excluding border
@@ -129,6 +169,60 @@ type warnModal struct {
 	content  string
 }
 
+// Action waiting for the label of a mark to be typed
+type pendingMarkAction int
+
+const (
+	noPendingMark pendingMarkAction = iota
+	setMarkPending
+	jumpToMarkPending
+)
+
+type marksModal struct {
+	picker picker.Model
+	// Labels of the marks, in the order of the picker items
+	labels []string
+	// Label of the mark being renamed, empty if not renaming
+	renaming  string
+	textInput textinput.Model
+}
+
+// Tag shown as a coloured label instead of its name
+type colorTag struct {
+	tag   string
+	color lipgloss.Color
+}
+
+type tagModal struct {
+	picker picker.Model
+	// Tags in the order of the picker items
+	tags []string
+	// Items whose tags are edited
+	targets []string
+}
+
+// Criterion of the select by modal, in the order of its items
+type selectCriterion int
+
+const (
+	selectByGlob selectCriterion = iota
+	selectByRegex
+	selectDirectories
+	selectImages
+	// Files modified in the last days, the number of days is typed
+	selectModifiedWithin
+)
+
+type selectByModal struct {
+	picker picker.Model
+	// Criterion whose value is being typed, while typing is true
+	criterion selectCriterion
+	typing    bool
+	textInput textinput.Model
+	// Error of the typed value, like an invalid regular expression
+	err string
+}
+
 type typingModal struct {
 	location  string
 	open      bool
@@ -155,30 +249,199 @@ type fileModel struct {
 	renaming     bool
 	maxFilePanel int
 	filePreview  filePreviewPanel
+	// Show the parent directory of the focused panel location on its left
+	millerColumns bool
+	parentColumn  parentColumn
 }
 
 type filePreviewPanel struct {
 	open  bool
 	width int
+	// Previewed while the cursor stays on its item, nil otherwise
+	gitDiff *gitDiff
 }
 
-// Panel representing a file
-type filePanel struct {
-	cursor             int
-	render             int
-	focusType          filePanelFocusType
+// Items of the parent directory of the focused panel location, in the Miller
+// columns layout
+type parentColumn struct {
+	// Zero when the window is too narrow for the column
+	width              int
 	location           string
-	sortOptions        sortOptionsModel
-	panelMode          panelMode
-	selected           []string
 	element            []element
-	directoryRecords   map[string]directoryRecord
-	rename             textinput.Model
-	renaming           bool
-	searchBar          textinput.Model
 	lastTimeGetElement time.Time
 }
 
+// Panel representing a file
+type filePanel struct {
+	cursor           int
+	render           int
+	focusType        filePanelFocusType
+	location         string
+	sortOptions      sortOptionsModel
+	panelMode        panelMode
+	selected         []string
+	element          []element
+	directoryRecords map[string]directoryRecord
+	rename           textinput.Model
+	renaming         bool
+	searchBar        textinput.Model
+	searchMode       matchMode
+	// Keep the search bar filter when entering a subdirectory
+	searchPinned       bool
+	lastTimeGetElement time.Time
+	history            locationHistory
+	finder             finder
+	fuzzySearch        fuzzySearch
+	// List the content of expanded directories below them, except while
+	// searching
+	treeView     bool
+	expandedDirs map[string]bool
+	// List all the items below the location, named by their relative path
+	flatten bool
+	// Show the stats of items in columns next to their names
+	detailView bool
+	// Only list the items carrying this tag, all items when empty
+	tagFilter string
+	// Hide the items matched by ignore files and hidden patterns
+	hideIgnored bool
+	// Item where the range being selected starts, empty when not selecting a
+	// range. The range is selected on top of rangeBase, the items selected
+	// before it started
+	rangeAnchor string
+	rangeBase   []string
+	// Directories read for the listing, the location and the expanded
+	// directories in tree view, or all the listed directories in flatten mode
+	listedDirs []string
+	// Listing kept up to date from the events of the watched directories,
+	// zero while the panel is polled
+	watchedListing listingState
+	// Items filtered by the search bar, kept while searching so that the query
+	// can change without reading the directory again. Read again when
+	// searchedListing, the listing state without the query, differs
+	searchedElements []element
+	searchedListing  listingState
+	flatWalk         flatWalk
+	// Total size of the selected items, shown in the footer in select mode
+	selectedSize itemsSizeSummary
+
+	// Tabs of the panel, empty while the panel has a single tab. State of the
+	// active tab lives in the fields above, its entry in tabs is only updated
+	// when switching away from it.
+	tabs      []filePanelTab
+	activeTab int
+}
+
+// What a listing of the panel depends on, besides the content of the listed
+// directories
+type listingState struct {
+	location         string
+	sortType         int
+	reversed         bool
+	directoriesFirst bool
+	displayDotFile   bool
+	flatten          bool
+	treeView         bool
+	search           string
+	searchMode       matchMode
+	tagFilter        string
+	hideIgnored      bool
+}
+
+// How names, or lines for the content search, are matched against the query
+// of the search bar or the recursive find
+type matchMode int
+
+const (
+	fuzzyMatch matchMode = iota
+	globMatch
+	regexMatch
+	// Substring match
+	exactMatch
+)
+
+// Recursive find in the subtree of a file panel location. While active, the
+// panel lists the results instead of the content of its location
+type finder struct {
+	active bool
+	// Search the content of files instead of their names
+	content   bool
+	textInput textinput.Model
+	mode      matchMode
+	// Identifies the latest search, results of older searches are dropped
+	id      int
+	running bool
+	cancel  context.CancelFunc
+	// Error of the query, like an invalid regular expression
+	err string
+}
+
+// Fuzzy search of the search bar, run in the background so that large
+// directories don't freeze the UI on each keystroke
+type fuzzySearch struct {
+	// Reused while the names in the panel location don't change
+	searcher *utils.FzfSearcher
+	location string
+	query    string
+	// Identifies the latest search, results of older searches are dropped
+	id     int
+	cancel context.CancelFunc
+}
+
+// Walk of the items below the location of a panel in flatten mode, run in
+// the background so that huge trees don't freeze the UI
+type flatWalk struct {
+	// Listing the walk ran for, without the search and the tag filter, which
+	// are applied to its items
+	state     listingState
+	elements  []element
+	truncated bool
+	// The directories changed, walk them again on the next read
+	stale bool
+	// Item the cursor is placed on once the items come in
+	cursorLocation string
+	// Identifies the latest walk, items of older walks are dropped
+	id       int
+	running  bool
+	cancel   context.CancelFunc
+	doneTime time.Time
+}
+
+// Items below a location, sorted, listed by a flatten walk
+type flatElements struct {
+	id       int
+	elements []element
+	// The walk stopped at maxFlattenItems
+	truncated bool
+}
+
+// Matches of a fuzzy search, ordered by score
+type fuzzySearchResults struct {
+	id       int
+	elements []element
+}
+
+// Batch of matches sent by a running find
+type findResults struct {
+	id       int
+	elements []element
+	done     bool
+}
+
+// Saved state of a file panel tab
+type filePanelTab struct {
+	location         string
+	cursor           int
+	render           int
+	sortOptions      sortOptionsModelData
+	panelMode        panelMode
+	selected         []string
+	directoryRecords map[string]directoryRecord
+	searchBarValue   string
+	searchMode       matchMode
+	searchPinned     bool
+	history          locationHistory
+}
+
 // Sort options
 type sortOptionsModel struct {
 	width  int
@@ -192,6 +455,8 @@ type sortOptionsModelData struct {
 	options  []string
 	selected int
 	reversed bool
+	// List directories before files, whatever the order
+	directoriesFirst bool
 }
 
 // Record for directory navigation
@@ -200,12 +465,30 @@ type directoryRecord struct {
 	directoryRender int
 }
 
+// Visited locations of a file panel for back and forward navigation.
+// index points to the current location in locations
+type locationHistory struct {
+	locations []string
+	index     int
+}
+
 // Element within a file panel
 type element struct {
 	name      string
 	location  string
 	directory bool
 	metaData  [][2]string
+	// Line of the match for content search results, 0 otherwise
+	line int
+	// Indexes of the runes of name matched by the fuzzy search
+	matchPositions []int
+	// Level below the panel location in tree view, and the indentation guides
+	// drawn before name
+	depth      int
+	treePrefix string
+	// Stats of the item when it was listed, without following symlinks. Can
+	// be nil, like for find results
+	info os.FileInfo
 }
 
 /* FILE WINDOWS TYPE END*/
@@ -234,13 +517,24 @@ type process struct {
 
 // Message for process bar
 type channelMessage struct {
-	messageID       string
-	messageType     channelMessageType
-	processNewState process
-	warnModal       warnModal
-	metadata        [][2]string
+	messageID          string
+	messageType        channelMessageType
+	processNewState    process
+	warnModal          warnModal
+	metadata           [][2]string
+	findResults        findResults
+	fuzzySearchResults fuzzySearchResults
+	flatElements       flatElements
+	gitDiff            gitDiff
 }
 
 /*PROCESS BAR internal TYPE END*/
 
 type editorFinishedMsg struct{ err error }
+
+// Position and time of the last mouse click, used to detect double clicks
+type mouseClickRecord struct {
+	time time.Time
+	x    int
+	y    int
+}
@@ -45,19 +45,20 @@ func filePanelSlice(dir []string) []filePanel {
 }
 
 func defaultFilePanel(dir string) filePanel {
-	return filePanel{
+	panel := filePanel{
 		render:   0,
 		cursor:   0,
 		location: dir,
 		sortOptions: sortOptionsModel{
 			width:  20,
-			height: 4,
+			height: len(common.SortTypeNames()) + 1,
 			open:   false,
 			cursor: common.Config.DefaultSortType,
 			data: sortOptionsModelData{
-				options:  []string{"Name", "Size", "Date Modified"},
-				selected: common.Config.DefaultSortType,
-				reversed: common.Config.SortOrderReversed,
+				options:          common.SortTypeNames(),
+				selected:         common.Config.DefaultSortType,
+				reversed:         common.Config.SortOrderReversed,
+				directoriesFirst: common.Config.SortDirectoriesFirst,
 			},
 		},
 		panelMode:        browserMode,
@@ -65,6 +66,7 @@ func defaultFilePanel(dir string) filePanel {
 		directoryRecords: make(map[string]directoryRecord),
 		searchBar:        common.GenerateSearchBar(),
 	}
+	return panel
 }
 
 // ================ String method for easy logging =====================
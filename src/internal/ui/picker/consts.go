@@ -0,0 +1,8 @@
+package picker
+
+// Keys moving the cursor while filtering. Other list movement hotkeys
+// are printable characters, which are needed for typing the filter
+const (
+	filterListUpKey   = "up"
+	filterListDownKey = "down"
+)
@@ -0,0 +1,216 @@
+package picker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/utils"
+)
+
+func New(headline string) Model {
+	return Model{
+		headline: headline,
+	}
+}
+
+// New picker whose items are fuzzy filtered by the text typed by the user
+func NewFilterable(headline string) Model {
+	return Model{
+		headline:   headline,
+		filterable: true,
+		textInput:  common.GeneratePromptTextInput(),
+	}
+}
+
+// Open the picker with items, placing the cursor at cursor
+func (m *Model) Open(items []string, cursor int) {
+	m.open = true
+	m.items = items
+	m.textInput.SetValue("")
+	if m.filterable {
+		_ = m.textInput.Focus()
+	}
+	m.filter()
+	if cursor >= 0 && cursor < len(items) {
+		m.cursor = cursor
+		m.render = max(0, cursor-m.listHeight()+1)
+	}
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.items = nil
+	m.shown = nil
+	m.cursor = 0
+	m.render = 0
+	m.textInput.SetValue("")
+	m.textInput.Blur()
+}
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+// Index in items of the item under the cursor. False if no item is shown
+func (m *Model) Selected() (int, bool) {
+	if len(m.shown) == 0 {
+		return 0, false
+	}
+	return m.shown[m.cursor], true
+}
+
+// Text typed to filter the items
+func (m *Model) Query() string {
+	return m.textInput.Value()
+}
+
+// Items the picker was opened with
+func (m *Model) Items() []string {
+	return m.items
+}
+
+func (m *Model) SetSize(width int, height int) {
+	m.width = width
+	m.height = height
+	m.textInput.Width = width - 4
+}
+
+// Lines taken by the headline, the filter input and dividers
+func (m *Model) headerHeight() int {
+	if m.filterable {
+		return 4
+	}
+	return 2
+}
+
+// Number of items that fit in the modal
+func (m *Model) listHeight() int {
+	return max(m.height-m.headerHeight(), 1)
+}
+
+// Update shown items based on the filter. Matching items keep their order,
+// so that callers can pass items already sorted by relevance
+func (m *Model) filter() {
+	m.cursor = 0
+	m.render = 0
+	m.shown = m.shown[:0]
+	query := m.textInput.Value()
+	if query == "" {
+		for i := range m.items {
+			m.shown = append(m.shown, i)
+		}
+		return
+	}
+	for _, match := range utils.FzfSearch(query, m.items) {
+		m.shown = append(m.shown, int(match.HayIndex))
+	}
+	slices.Sort(m.shown)
+}
+
+func (m *Model) ListUp() {
+	if len(m.shown) == 0 {
+		return
+	}
+	if m.cursor > 0 {
+		m.cursor--
+		if m.cursor < m.render {
+			m.render = m.cursor
+		}
+	} else {
+		m.cursor = len(m.shown) - 1
+		m.render = max(0, len(m.shown)-m.listHeight())
+	}
+}
+
+func (m *Model) ListDown() {
+	if len(m.shown) == 0 {
+		return
+	}
+	if m.cursor < len(m.shown)-1 {
+		m.cursor++
+		if m.cursor >= m.render+m.listHeight() {
+			m.render++
+		}
+	} else {
+		m.cursor = 0
+		m.render = 0
+	}
+}
+
+// Handle a key press while the picker is open. Returns the index in items of
+// the chosen item and true if the user confirmed a choice. The picker is closed
+// on confirm and cancel. While filtering, only arrow keys move the cursor, other
+// keys are left to UpdateState.
+func (m *Model) HandleKey(msg string) (int, bool) {
+	confirmKeys, cancelKeys := common.Hotkeys.Confirm, common.Hotkeys.Quit
+	upKeys, downKeys := common.Hotkeys.ListUp, common.Hotkeys.ListDown
+	if m.filterable {
+		confirmKeys, cancelKeys = common.Hotkeys.ConfirmTyping, common.Hotkeys.CancelTyping
+		upKeys, downKeys = []string{filterListUpKey}, []string{filterListDownKey}
+	}
+
+	switch {
+	case slices.Contains(upKeys, msg):
+		m.ListUp()
+	case slices.Contains(downKeys, msg):
+		m.ListDown()
+	case slices.Contains(confirmKeys, msg):
+		if len(m.shown) == 0 {
+			m.Close()
+			return 0, false
+		}
+		chosen := m.shown[m.cursor]
+		m.Close()
+		return chosen, true
+	case slices.Contains(cancelKeys, msg), slices.Contains(common.Hotkeys.CancelTyping, msg):
+		m.Close()
+	}
+	return 0, false
+}
+
+// Update the filter input. Should be called for every message while the picker is open
+func (m *Model) UpdateState(msg tea.Msg) tea.Cmd {
+	if !m.open || !m.filterable {
+		return nil
+	}
+	var cmd tea.Cmd
+	query := m.textInput.Value()
+	m.textInput, cmd = m.textInput.Update(msg)
+	if m.textInput.Value() != query {
+		m.filter()
+	}
+	return cmd
+}
+
+// Render the picker. Items are truncated at the beginning as they are
+// usually paths, where the end is the most relevant part
+func (m *Model) Render() string {
+	divider := strings.Repeat(common.Config.BorderTop, m.width)
+	content := common.ModalTitleStyle.Render(" "+m.headline) + "\n" + divider
+	if m.filterable {
+		content += "\n " + common.ModalStyle.Render(icon.Search+icon.Space) + m.textInput.View() + "\n" + divider
+	}
+
+	if len(m.shown) == 0 {
+		content += "\n" + common.ModalStyle.Render(" "+icon.Error+"  Nothing to show")
+	}
+	for i := m.render; i < m.render+m.listHeight() && i < len(m.shown); i++ {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = common.FilePanelCursorStyle.Render(icon.Cursor + " ")
+		}
+		item := m.items[m.shown[i]]
+		content += "\n" + cursor + common.ModalStyle.Render(common.TruncateTextBeginning(item, m.width-3, "..."))
+	}
+
+	countString := "0/0"
+	if len(m.shown) > 0 {
+		countString = fmt.Sprintf("%d/%d", m.cursor+1, len(m.shown))
+	}
+	bottomBorder := common.GenerateFooterBorder(countString, m.width-2)
+	return common.PickerModalBorderStyle(m.height, m.width, bottomBorder).Render(content)
+}
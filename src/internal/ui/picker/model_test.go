@@ -0,0 +1,70 @@
+package picker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel_ListUpDown(t *testing.T) {
+	m := New("test")
+	// Room for three items
+	m.SetSize(20, m.headerHeight()+3)
+	m.Open([]string{"a", "b", "c", "d", "e"}, 4)
+	assert.True(t, m.IsOpen())
+	assert.Equal(t, 4, m.cursor)
+	assert.Equal(t, 2, m.render)
+
+	m.ListDown()
+	assert.Equal(t, 0, m.cursor)
+	assert.Equal(t, 0, m.render)
+
+	m.ListUp()
+	assert.Equal(t, 4, m.cursor)
+	assert.Equal(t, 2, m.render)
+
+	m.ListUp()
+	m.ListUp()
+	m.ListUp()
+	assert.Equal(t, 1, m.cursor)
+	assert.Equal(t, 1, m.render)
+
+	m.Close()
+	assert.False(t, m.IsOpen())
+	assert.Empty(t, m.items)
+}
+
+func TestModel_OpenEmpty(t *testing.T) {
+	m := New("test")
+	m.SetSize(20, 10)
+	m.Open(nil, 3)
+	m.ListDown()
+	m.ListUp()
+	assert.Equal(t, 0, m.cursor)
+	_, ok := m.Selected()
+	assert.False(t, ok)
+}
+
+func TestModel_Filter(t *testing.T) {
+	m := NewFilterable("test")
+	m.SetSize(40, 10)
+	m.Open([]string{"/home/user/projects", "/tmp", "/home/user/pictures"}, 0)
+	assert.Equal(t, []int{0, 1, 2}, m.shown)
+
+	m.textInput.SetValue("pro")
+	m.filter()
+	assert.Equal(t, []int{0}, m.shown)
+
+	m.textInput.SetValue("hmusr")
+	m.filter()
+	assert.Equal(t, []int{0, 2}, m.shown, "matches should keep the order of items")
+	m.ListDown()
+	selected, ok := m.Selected()
+	assert.True(t, ok)
+	assert.Equal(t, 2, selected)
+
+	m.textInput.SetValue("zzz")
+	m.filter()
+	assert.Empty(t, m.shown)
+	assert.Equal(t, "zzz", m.Query())
+}
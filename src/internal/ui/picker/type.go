@@ -0,0 +1,23 @@
+package picker
+
+import "github.com/charmbracelet/bubbles/textinput"
+
+// No need to name it as PickerModel. It will me imported as picker.Model
+type Model struct {
+	// Configuration
+	headline string
+	// Whether the items can be filtered by typing
+	filterable bool
+	// Width and height of the modal excluding border
+	width  int
+	height int
+
+	// State
+	open  bool
+	items []string
+	// Indexes in items of the items matching the filter, in display order
+	shown     []int
+	cursor    int
+	render    int
+	textInput textinput.Model
+}
@@ -60,8 +60,8 @@ func defaultCommandSlice() []promptCommand {
 		},
 		{
 			command:     CdCommand,
-			usage:       CdCommand + " <PATH>",
-			description: "Change directory of current panel",
+			usage:       CdCommand + " <PATH|KEYWORDS...>",
+			description: "Change directory of current panel, keywords jump to the best ranked match",
 		},
 	}
 }
@@ -35,12 +35,16 @@ func getPromptAction(shellMode bool, value string, cwdLocation string) (common.M
 		}
 		return common.SplitPanelAction{}, nil
 	case "cd":
-		if len(promptArgs) != 2 {
+		if len(promptArgs) < 2 {
 			return noAction, invalidCmdError{
-				uiMsg: fmt.Sprintf("cd command needs exactly one argument, received %d",
-					len(promptArgs)-1),
+				uiMsg: "cd command needs at least one argument, received 0",
 			}
 		}
+		if len(promptArgs) > 2 {
+			return common.CDCurrentPanelAction{
+				Keywords: promptArgs[1:],
+			}, nil
+		}
 		return common.CDCurrentPanelAction{
 			Location: promptArgs[1],
 		}, nil
@@ -60,7 +60,7 @@ func TestModel_getPromptAction(t *testing.T) {
 			shellMode:      false,
 			expectecAction: common.NoAction{},
 			expectedErr:    true,
-			expectedErrMsg: "cd command needs exactly one argument, received 0",
+			expectedErrMsg: "cd command needs at least one argument, received 0",
 		},
 		{
 			name:           "Invalid command",
@@ -86,6 +86,14 @@ func TestModel_getPromptAction(t *testing.T) {
 			expectedErr:    false,
 			expectedErrMsg: "",
 		},
+		{
+			name:           "cd with keywords",
+			text:           CdCommand + " proj api",
+			shellMode:      false,
+			expectecAction: common.CDCurrentPanelAction{Keywords: []string{"proj", "api"}},
+			expectedErr:    false,
+			expectedErrMsg: "",
+		},
 		{
 			name:           "Correct open command",
 			text:           OpenCommand + " /abc",
@@ -12,5 +12,10 @@ var diskDividerDir = directory{ //nolint: gochecknoglobals // This is more like
 	Location: "Disks+-*/=?",
 }
 
+var taggedDividerDir = directory{ //nolint: gochecknoglobals // This is more like a const.
+	Name:     "",
+	Location: "Tagged+-*/=?",
+}
+
 // superfile logo + blank line + search bar
 const sideBarInitialHeight = 3
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"slices"
 
+	"github.com/yorukot/superfile/src/internal/tags"
 	"github.com/yorukot/superfile/src/internal/utils"
 
 	"github.com/adrg/xdg"
@@ -16,8 +17,9 @@ import (
 )
 
 // Return all sidebar directories
-func getDirectories() []directory {
-	return formDirctorySlice(getWellKnownDirectories(), getPinnedDirectories(), getExternalMediaFolders())
+func getDirectories(tagStore *tags.Store) []directory {
+	return appendTaggedFiles(formDirctorySlice(getWellKnownDirectories(), getPinnedDirectories(), getExternalMediaFolders()),
+		getTaggedFiles(tagStore))
 }
 
 func formDirctorySlice(homeDirectories []directory, pinnedDirectories []directory, diskDirectories []directory) []directory {
@@ -33,6 +35,16 @@ func formDirctorySlice(homeDirectories []directory, pinnedDirectories []director
 	return directories
 }
 
+// Add the section of tagged files after the other directories, unless there
+// are no tagged files
+func appendTaggedFiles(directories []directory, taggedFiles []directory) []directory {
+	if len(taggedFiles) == 0 {
+		return directories
+	}
+	directories = append(directories, taggedDividerDir)
+	return append(directories, taggedFiles...)
+}
+
 // Return system default directory e.g. Home, Downloads, etc
 func getWellKnownDirectories() []directory {
 	wellKnownDirectories := []directory{
@@ -80,6 +92,18 @@ func getPinnedDirectories() []directory {
 	return directories
 }
 
+// Get the files carrying a tag that still exist
+func getTaggedFiles(tagStore *tags.Store) []directory {
+	files := []directory{}
+	for _, path := range tagStore.Files() {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		files = append(files, directory{Location: path, Name: filepath.Base(path)})
+	}
+	return files
+}
+
 // Fuzzy search function for a list of directories.
 func fuzzySearch(query string, dirs []directory) []directory {
 	if len(dirs) == 0 {
@@ -99,6 +123,7 @@ func fuzzySearch(query string, dirs []directory) []directory {
 
 	for _, match := range utils.FzfSearch(query, haystack) {
 		if d, ok := dirMap[match.Key]; ok {
+			d.matchPositions = match.Positions
 			filteredDirs = append(filteredDirs, d)
 		}
 	}
@@ -106,13 +131,13 @@ func fuzzySearch(query string, dirs []directory) []directory {
 	return filteredDirs
 }
 
-// Get filtered directories using fuzzy search logic with three haystacks.
-func getFilteredDirectories(query string) []directory {
-	return formDirctorySlice(
+// Get filtered directories using fuzzy search logic with four haystacks.
+func getFilteredDirectories(query string, tagStore *tags.Store) []directory {
+	return appendTaggedFiles(formDirctorySlice(
 		fuzzySearch(query, getWellKnownDirectories()),
 		fuzzySearch(query, getPinnedDirectories()),
 		fuzzySearch(query, getExternalMediaFolders()),
-	)
+	), fuzzySearch(query, getTaggedFiles(tagStore)))
 }
 
 // TogglePinnedDirectory adds or removes a directory from the pinned directories list
@@ -117,3 +117,46 @@ func (s *Model) updateRenderIndex(mainPanelHeight int) {
 	slog.Error("Unexpected situation in updateRenderIndex", "cursor", s.cursor,
 		"renderIndex", s.renderIndex, "directory count", len(s.directories))
 }
+
+// Return the index of the directory rendered at the given line of the sidebar content.
+// line is counted from the top of the content, excluding the border.
+// Returns -1 if no directory is rendered at that line, e.g. for dividers or empty lines
+func (s *Model) directoryIndexAtLine(line int, mainPanelHeight int, searchBarShown bool) int {
+	// Title and a blank line are always rendered. Search bar takes one more line
+	curLine := sideBarInitialHeight - 1
+	if searchBarShown {
+		curLine++
+	}
+	totalHeight := sideBarInitialHeight
+	for i := s.renderIndex; i < len(s.directories); i++ {
+		if totalHeight+s.directories[i].RequiredHeight() > mainPanelHeight {
+			break
+		}
+		totalHeight += s.directories[i].RequiredHeight()
+		if s.directories[i].IsDivider() {
+			curLine += s.directories[i].RequiredHeight()
+			continue
+		}
+		if curLine == line {
+			return i
+		}
+		curLine++
+	}
+	return -1
+}
+
+// ClickDirectory moves the cursor to the directory rendered at the given line of
+// the sidebar content (excluding border). isSidebarFocussed must be the focus state that
+// was used for the last render. Returns true if a directory was found at that line
+func (s *Model) ClickDirectory(line int, mainPanelHeight int, isSidebarFocussed bool) bool {
+	if s.NoActualDir() {
+		return false
+	}
+	searchBarShown := s.searchBar.Focused() || s.searchBar.Value() != "" || isSidebarFocussed
+	idx := s.directoryIndexAtLine(line, mainPanelHeight, searchBarShown)
+	if idx == -1 {
+		return false
+	}
+	s.cursor = idx
+	return true
+}
@@ -596,3 +596,107 @@ func Test_listDown(t *testing.T) {
 		})
 	}
 }
+
+func Test_directoryIndexAtLine(t *testing.T) {
+	// 2 home dirs, divider, 1 pinned dir, divider, 2 disk dirs
+	sidebarA := Model{
+		directories: formDirctorySlice(
+			dirSlice(2), dirSlice(1), dirSlice(2),
+		),
+	}
+	sidebarB := Model{
+		directories: formDirctorySlice(
+			dirSlice(2), dirSlice(1), dirSlice(2),
+		),
+		renderIndex: 3,
+	}
+
+	testCases := []struct {
+		name            string
+		sidebar         Model
+		line            int
+		mainPanelHeight int
+		searchBarShown  bool
+		expectedIndex   int
+	}{
+		{
+			name:            "Title line",
+			sidebar:         sidebarA,
+			line:            0,
+			mainPanelHeight: 100,
+			expectedIndex:   -1,
+		},
+		{
+			name:            "First directory without search bar",
+			sidebar:         sidebarA,
+			line:            2,
+			mainPanelHeight: 100,
+			expectedIndex:   0,
+		},
+		{
+			name:            "Search bar line",
+			sidebar:         sidebarA,
+			line:            2,
+			mainPanelHeight: 100,
+			searchBarShown:  true,
+			expectedIndex:   -1,
+		},
+		{
+			name:            "First directory with search bar",
+			sidebar:         sidebarA,
+			line:            3,
+			mainPanelHeight: 100,
+			searchBarShown:  true,
+			expectedIndex:   0,
+		},
+		{
+			name:            "Pinned divider",
+			sidebar:         sidebarA,
+			line:            5,
+			mainPanelHeight: 100,
+			expectedIndex:   -1,
+		},
+		{
+			name:            "Pinned directory after divider",
+			sidebar:         sidebarA,
+			line:            7,
+			mainPanelHeight: 100,
+			expectedIndex:   3,
+		},
+		{
+			name:            "Last disk directory",
+			sidebar:         sidebarA,
+			line:            12,
+			mainPanelHeight: 100,
+			expectedIndex:   6,
+		},
+		{
+			name:            "Line after last directory",
+			sidebar:         sidebarA,
+			line:            13,
+			mainPanelHeight: 100,
+			expectedIndex:   -1,
+		},
+		{
+			name:            "Directory not rendered due to small height",
+			sidebar:         sidebarA,
+			line:            7,
+			mainPanelHeight: 8,
+			expectedIndex:   -1,
+		},
+		{
+			name:            "Non zero render index",
+			sidebar:         sidebarB,
+			line:            2,
+			mainPanelHeight: 100,
+			expectedIndex:   3,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedIndex,
+				tt.sidebar.directoryIndexAtLine(tt.line, tt.mainPanelHeight, tt.searchBarShown))
+		})
+	}
+}
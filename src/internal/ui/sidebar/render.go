@@ -51,11 +51,13 @@ func (s *Model) directoriesRender(mainPanelHeight int, curFilePanelFileLocation
 
 		totalHeight += s.directories[i].RequiredHeight()
 
-		switch s.directories[i] {
-		case pinnedDividerDir:
+		switch s.directories[i].Location {
+		case pinnedDividerDir.Location:
 			res += "\n" + common.SideBarPinnedDivider
-		case diskDividerDir:
+		case diskDividerDir.Location:
 			res += "\n" + common.SideBarDisksDivider
+		case taggedDividerDir.Location:
+			res += "\n" + common.SideBarTaggedDivider
 		default:
 			cursor := " "
 			if s.cursor == i && sideBarFocussed && !s.searchBar.Focused() {
@@ -69,7 +71,8 @@ func (s *Model) directoriesRender(mainPanelHeight int, curFilePanelFileLocation
 					renderStyle = common.SidebarSelectedStyle
 				}
 				res += common.FilePanelCursorStyle.Render(cursor+" ") +
-					renderStyle.Render(common.TruncateText(s.directories[i].Name, common.Config.SidebarWidth-2, "..."))
+					common.RenderMatchedText(s.directories[i].Name, common.Config.SidebarWidth-2,
+						s.directories[i].matchPositions, renderStyle)
 			}
 		}
 	}
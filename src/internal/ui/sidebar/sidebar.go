@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	variable "github.com/yorukot/superfile/src/config"
 	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/tags"
 )
 
 // Rename file where the cursor is located
@@ -96,9 +97,9 @@ func (s *Model) HandleSearchBarKey(msg string) {
 // which is a disk heavy operation.
 func (s *Model) UpdateDirectories() {
 	if s.searchBar.Value() != "" {
-		s.directories = getFilteredDirectories(s.searchBar.Value())
+		s.directories = getFilteredDirectories(s.searchBar.Value(), s.tags)
 	} else {
-		s.directories = getDirectories()
+		s.directories = getDirectories(s.tags)
 	}
 	// This is needed, as due to filtering, the cursor might be invalid
 	if s.isCursorInvalid() {
@@ -107,10 +108,11 @@ func (s *Model) UpdateDirectories() {
 }
 
 // New creates a new sidebar model with the given parameters
-func New() Model {
+func New(tagStore *tags.Store) Model {
 	return Model{
 		renderIndex: 0,
-		directories: getDirectories(),
+		directories: getDirectories(tagStore),
 		searchBar:   common.GenerateSearchBar(),
+		tags:        tagStore,
 	}
 }
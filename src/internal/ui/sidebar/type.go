@@ -1,10 +1,15 @@
 package sidebar
 
-import "github.com/charmbracelet/bubbles/textinput"
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/yorukot/superfile/src/internal/tags"
+)
 
 type directory struct {
 	Location string `json:"location"`
 	Name     string `json:"name"`
+	// Indexes of the runes of Name matched by the search query
+	matchPositions []int
 }
 
 type Model struct {
@@ -14,4 +19,6 @@ type Model struct {
 	rename      textinput.Model
 	renaming    bool
 	searchBar   textinput.Model
+	// Tagged files are listed in their own section, when there are some
+	tags *tags.Store
 }
@@ -1,7 +1,8 @@
 package sidebar
 
 func (d directory) IsDivider() bool {
-	return d == pinnedDividerDir || d == diskDividerDir
+	return d.Location == pinnedDividerDir.Location || d.Location == diskDividerDir.Location ||
+		d.Location == taggedDividerDir.Location
 }
 func (d directory) RequiredHeight() int {
 	if d.IsDivider() {
@@ -78,10 +79,10 @@ func (s *Model) pinnedIndexRange() (int, int) {
 	pinnedDividerIdx := -1
 	diskDividerIdx := -1
 	for i, d := range s.directories {
-		if d == pinnedDividerDir {
+		if d.Location == pinnedDividerDir.Location {
 			pinnedDividerIdx = i
 		}
-		if d == diskDividerDir {
+		if d.Location == diskDividerDir.Location {
 			diskDividerIdx = i
 			break
 		}
@@ -1,10 +1,15 @@
 package sidebar
 
 import (
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/tags"
 )
 
 func dirSlice(count int) []directory {
@@ -154,3 +159,34 @@ func Test_resetCursor(t *testing.T) {
 		})
 	}
 }
+
+func Test_fuzzySearchMatchPositions(t *testing.T) {
+	dirs := []directory{
+		{Name: "Documents", Location: "/home/user/Documents"},
+		{Name: "Downloads", Location: "/home/user/Downloads"},
+	}
+	result := fuzzySearch("dl", dirs)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Downloads", result[0].Name)
+	assert.ElementsMatch(t, []int{0, 4}, result[0].matchPositions)
+	assert.Empty(t, fuzzySearch("", dirs)[0].matchPositions)
+}
+
+func Test_taggedFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(file, nil, 0644))
+	tagStore := tags.New(filepath.Join(dir, "tags.json"))
+	require.NoError(t, tagStore.Add(file, "todo"))
+	require.NoError(t, tagStore.Add(filepath.Join(dir, "missing.txt"), "todo"))
+
+	tagged := getTaggedFiles(tagStore)
+	assert.Equal(t, []directory{{Location: file, Name: "a.txt"}}, tagged)
+	assert.Empty(t, getTaggedFiles(nil))
+
+	directories := appendTaggedFiles(fullDirSlice(1), tagged)
+	assert.Len(t, directories, 7)
+	assert.True(t, directories[5].IsDivider())
+	assert.Equal(t, file, directories[6].Location)
+	assert.Len(t, appendTaggedFiles(fullDirSlice(1), nil), 5, "no section without tagged files")
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// SameDevice reports whether the files at paths a and b are on the same
+// volume
+func SameDevice(a string, b string) bool {
+	return strings.EqualFold(filepath.VolumeName(a), filepath.VolumeName(b))
+}
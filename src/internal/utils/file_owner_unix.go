@@ -0,0 +1,41 @@
+//go:build !windows
+
+package utils
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+	"sync"
+	"syscall"
+)
+
+// User names by uid, as looking them up reads /etc/passwd
+var (
+	ownerNamesMutex sync.Mutex        //nolint: gochecknoglobals // Guards ownerNames
+	ownerNames      map[string]string //nolint: gochecknoglobals // Cache shared by all file panels
+)
+
+// FileOwner returns the name of the user owning the file described by info,
+// or its uid if the name can't be found
+func FileOwner(info os.FileInfo) string {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ""
+	}
+	uid := strconv.FormatUint(uint64(stat.Uid), 10)
+	ownerNamesMutex.Lock()
+	defer ownerNamesMutex.Unlock()
+	if name, ok := ownerNames[uid]; ok {
+		return name
+	}
+	name := uid
+	if owner, err := user.LookupId(uid); err == nil {
+		name = owner.Username
+	}
+	if ownerNames == nil {
+		ownerNames = make(map[string]string)
+	}
+	ownerNames[uid] = name
+	return name
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package utils
+
+import "os"
+
+// FileOwner returns an empty string, as file ownership is not exposed through
+// os.FileInfo on Windows
+func FileOwner(_ os.FileInfo) string {
+	return ""
+}
@@ -0,0 +1,29 @@
+//go:build darwin || freebsd || netbsd
+
+package utils
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// FileAccessTime returns the last access time of the file described by info,
+// or its modification time if it is unknown
+func FileAccessTime(info os.FileInfo) time.Time {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.ModTime()
+	}
+	return time.Unix(stat.Atimespec.Unix())
+}
+
+// FileBirthTime returns the creation time of the file at path, described by
+// info, or its modification time if it is unknown
+func FileBirthTime(_ string, info os.FileInfo) time.Time {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.ModTime()
+	}
+	return time.Unix(stat.Birthtimespec.Unix())
+}
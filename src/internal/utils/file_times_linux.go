@@ -0,0 +1,32 @@
+//go:build linux
+
+package utils
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// FileAccessTime returns the last access time of the file described by info,
+// or its modification time if it is unknown
+func FileAccessTime(info os.FileInfo) time.Time {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.ModTime()
+	}
+	return time.Unix(stat.Atim.Unix())
+}
+
+// FileBirthTime returns the creation time of the file at path, described by
+// info, or its modification time if the file system doesn't record it
+func FileBirthTime(path string, info os.FileInfo) time.Time {
+	var stat unix.Statx_t
+	err := unix.Statx(unix.AT_FDCWD, path, unix.AT_SYMLINK_NOFOLLOW, unix.STATX_BTIME, &stat)
+	if err != nil || stat.Mask&unix.STATX_BTIME == 0 {
+		return info.ModTime()
+	}
+	return time.Unix(stat.Btime.Sec, int64(stat.Btime.Nsec))
+}
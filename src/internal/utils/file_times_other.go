@@ -0,0 +1,20 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !windows
+
+package utils
+
+import (
+	"os"
+	"time"
+)
+
+// FileAccessTime returns the modification time of the file described by
+// info, as its access time can't be read on this platform
+func FileAccessTime(info os.FileInfo) time.Time {
+	return info.ModTime()
+}
+
+// FileBirthTime returns the modification time of the file described by info,
+// as its creation time can't be read on this platform
+func FileBirthTime(_ string, info os.FileInfo) time.Time {
+	return info.ModTime()
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package utils
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// FileAccessTime returns the last access time of the file described by info,
+// or its modification time if it is unknown
+func FileAccessTime(info os.FileInfo) time.Time {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return info.ModTime()
+	}
+	return time.Unix(0, data.LastAccessTime.Nanoseconds())
+}
+
+// FileBirthTime returns the creation time of the file at path, described by
+// info, or its modification time if it is unknown
+func FileBirthTime(_ string, info os.FileInfo) time.Time {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return info.ModTime()
+	}
+	return time.Unix(0, data.CreationTime.Nanoseconds())
+}
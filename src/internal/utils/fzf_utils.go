@@ -1,14 +1,97 @@
 package utils
 
-import "github.com/reinhrst/fzf-lib"
+import (
+	"context"
+	"errors"
+	"sync"
 
-// Returning a string slice causes inefficiency in current usage
+	"github.com/reinhrst/fzf-lib"
+)
+
+var errFzfSearcherEnded = errors.New("fzf searcher already ended")
+
+// FzfSearcher keeps an fzf instance for a haystack, so that successive
+// queries over the same items, like each keystroke in a search bar, don't
+// rebuild it. Searches are serialized, a search waits for the previous one to
+// return, which happens early when its context is cancelled.
+type FzfSearcher struct {
+	mu       sync.Mutex
+	fzf      *fzf.Fzf
+	haystack []string
+	ended    bool
+	// Whether the result of the last search is still to be read from the
+	// result channel. fzf blocks until results are read, so it must be drained
+	// before ending the instance
+	pending      bool
+	pendingQuery string
+}
+
+func NewFzfSearcher(haystack []string) *FzfSearcher {
+	return &FzfSearcher{
+		fzf:      fzf.New(haystack, fzf.DefaultOptions()),
+		haystack: haystack,
+	}
+}
+
+func (s *FzfSearcher) Haystack() []string {
+	return s.haystack
+}
+
+// Search returns the matches of query, ordered by score. It returns
+// ctx.Err() if ctx is done before the search completes.
+func (s *FzfSearcher) Search(ctx context.Context, query string) ([]fzf.MatchResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if s.ended {
+		return nil, errFzfSearcherEnded
+	}
+
+	s.fzf.Search(query)
+	s.pending = true
+	s.pendingQuery = query
+	for {
+		select {
+		case result := <-s.fzf.GetResultChannel():
+			// Results of abandoned searches can still be waiting in the channel
+			if result.Needle == query {
+				s.pending = false
+				return result.Matches, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// End stops the fzf instance. It may block until an abandoned search
+// completes, so it is best called in a goroutine.
+func (s *FzfSearcher) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	if s.pending {
+		for result := range s.fzf.GetResultChannel() {
+			if result.Needle == s.pendingQuery {
+				break
+			}
+		}
+	}
+	s.fzf.End()
+	s.ended = true
+}
+
+// FzfSearch returns the matches of query in source, ordered by score. It
+// blocks until the search completes, use a FzfSearcher to search in the
+// background.
 func FzfSearch(query string, source []string) []fzf.MatchResult {
-	fzfSearcher := fzf.New(source, fzf.DefaultOptions())
-	fzfSearcher.Search(query)
-	// Todo : This is a blocking call, which will cause the UI to freeze if the query is slow.
-	// Need to put a timeout on this
-	fzfResults := <-fzfSearcher.GetResultChannel()
-	fzfSearcher.End()
-	return fzfResults.Matches
+	searcher := NewFzfSearcher(source)
+	defer searcher.End()
+	// Can't fail, as the context is never cancelled
+	matches, _ := searcher.Search(context.Background(), query)
+	return matches
 }
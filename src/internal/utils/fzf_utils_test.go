@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFzfSearcher(t *testing.T) {
+	haystack := []string{"main.go", "model.go", "README.md"}
+	searcher := NewFzfSearcher(haystack)
+	defer searcher.End()
+
+	matches, err := searcher.Search(context.Background(), "mgo")
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "main.go", matches[0].Key)
+	assert.Equal(t, int32(0), matches[0].HayIndex)
+	assert.ElementsMatch(t, []int{0, 5, 6}, matches[0].Positions)
+
+	// The instance is reused for the following queries
+	matches, err = searcher.Search(context.Background(), "read")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "README.md", matches[0].Key)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = searcher.Search(ctx, "main")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFzfSearcherCancelledSearches(t *testing.T) {
+	haystack := make([]string, 100000)
+	for i := range haystack {
+		haystack[i] = "file_" + strconv.Itoa(i) + ".txt"
+	}
+	searcher := NewFzfSearcher(haystack)
+
+	// Abandon searches midway, like when typing in the search bar
+	for _, query := range []string{"f", "fi", "fil", "file_9"} {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = searcher.Search(ctx, query)
+		}()
+		cancel()
+		<-done
+	}
+	matches, err := searcher.Search(context.Background(), "file_99999")
+	require.NoError(t, err)
+	assert.Equal(t, "file_99999.txt", matches[0].Key)
+
+	// Must not block forever nor panic with abandoned searches
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_, _ = searcher.Search(ctx, "txt")
+	}()
+	cancel()
+	searcher.End()
+	_, err = searcher.Search(context.Background(), "file")
+	require.Error(t, err)
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// This file provides utilities for storing values in a file as JSON
+
+// ReadJSONFile decodes the content of the file at path into value, and
+// returns false if the file is missing or can't be decoded. Errors other than
+// a missing file are logged. Decode into a fresh value, as a failed decoding
+// can leave it partly filled
+func ReadJSONFile(path string, value any) bool {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+	if err != nil {
+		slog.Error("Error while reading JSON file", "path", path, "error", err)
+		return false
+	}
+	if err = json.Unmarshal(data, value); err != nil {
+		slog.Error("Error while parsing JSON file", "path", path, "error", err)
+		return false
+	}
+	return true
+}
+
+func WriteJSONFile(path string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error marshaling %s: %w", path, err)
+	}
+	if err = os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	var value map[string]int
+	assert.False(t, ReadJSONFile(path, &value), "missing file")
+
+	require.NoError(t, WriteJSONFile(path, map[string]int{"a": 1}))
+	require.True(t, ReadJSONFile(path, &value))
+	assert.Equal(t, map[string]int{"a": 1}, value)
+
+	require.NoError(t, os.WriteFile(path, []byte("{invalid"), 0644))
+	var invalid map[string]int
+	assert.False(t, ReadJSONFile(path, &invalid))
+	assert.False(t, ReadJSONFile(t.TempDir(), &invalid), "directory")
+
+	assert.Error(t, WriteJSONFile(path, func() {}), "value that can't be encoded")
+	assert.Error(t, WriteJSONFile(filepath.Join(path, "missing", "store.json"), value))
+}